@@ -28,6 +28,7 @@ func NewClient(tenant string, enterpriseID string, token string) *Client {
 		EnterpriseID: enterpriseID,
 		Auth:         auth,
 		HTTPClient:   httpClient,
+		Retry:        requests.DefaultRetryPolicy(),
 	}
 
 	device := resources.Device{Request: Request}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	esperio "github.com/Hasaber8/esper-go-sdk"
+	"github.com/Hasaber8/esper-go-sdk/resources"
 )
 
 func main() {
@@ -24,49 +25,42 @@ func main() {
 
 	client := esperio.NewClient("develop", enterpriseID, token)
 
-	devices := []string{"d774ae8c-7466-42df-a472-6f04b39b8907"}
-
-	// Example 1: raw OTA update command
-	fmt.Println("=== RAW OTA Update Command ===")
-	complexBody := map[string]interface{}{
-		"command_type": "DEVICE",
-		"command":      "UPDATE_DEVICE_CONFIG",
-		"command_args": map[string]interface{}{
-			"custom_settings_config": map[string]interface{}{
-				"scripts": []map[string]interface{}{
-					{
-						"action":     "LAUNCH",
-						"launchType": "SERVICE",
-						"actionParams": map[string]interface{}{
-							"componentName": "io.esper.otamanager/io.esper.otamanager.OTAUpdateService",
-							"intentAction":  "io.esper.otamanager.INSTALL_OTA",
-							"serviceType":   "BACKGROUND",
-							"extras": map[string]interface{}{
-								"otaType":  "SEAMLESS",
-								"metaData": `{"switch_slot_allowed":true,"ab_streaming_metadata":{"property_files":[{"filename":"payload_metadata.bin","size":"67033","offset":"2706"},{"filename":"payload.bin","size":"817266612","offset":"2706"},{"filename":"payload_properties.txt","size":"154","offset":"817269376"},{"filename":"apex_info.pb","size":"925","offset":"1569"},{"filename":"care_map.pb","size":"118","offset":"2541"},{"filename":"metadata","size":"653","offset":"69"},{"filename":"metadata.pb","size":"731","offset":"790"}]},"ab_install_type":"STREAMING","name":"13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne","header_key_value_pairs":["FILE_HASH=wPYevFfTewvnucpM3aRHF1RSxA2ELM6HY0Fp97AKBFA=","FILE_SIZE=817266612","METADATA_HASH=qbr5WBzNRvv8mybFXVQ1N6BNhpIX++0wnAN8VUhUAjI=","METADATA_SIZE=66766"],"url":"https://ota.esper.cloud/jenkins_builds/OSBuilds/sparrow/thirteen/arm64/1027/artifacts/foundation-13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne-fullota.zip"}`,
-							},
-						},
-					},
-				},
-			},
+	target := resources.DeviceTarget("d774ae8c-7466-42df-a472-6f04b39b8907")
+
+	// Example 1: seamless A/B OTA update
+	fmt.Println("=== OTA Update Command ===")
+	update := resources.OTAUpdate{
+		Name:         "13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne",
+		URL:          "https://ota.esper.cloud/jenkins_builds/OSBuilds/sparrow/thirteen/arm64/1027/artifacts/foundation-13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne-fullota.zip",
+		FileHash:     "wPYevFfTewvnucpM3aRHF1RSxA2ELM6HY0Fp97AKBFA=",
+		FileSize:     817266612,
+		MetadataHash: "qbr5WBzNRvv8mybFXVQ1N6BNhpIX++0wnAN8VUhUAjI=",
+		MetadataSize: 66766,
+		PropertyFiles: []resources.OTAPropertyFile{
+			{Filename: "payload_metadata.bin", Size: 67033, Offset: 2706},
+			{Filename: "payload.bin", Size: 817266612, Offset: 2706},
+			{Filename: "payload_properties.txt", Size: 154, Offset: 817269376},
+			{Filename: "apex_info.pb", Size: 925, Offset: 1569},
+			{Filename: "care_map.pb", Size: 118, Offset: 2541},
+			{Filename: "metadata", Size: 653, Offset: 69},
+			{Filename: "metadata.pb", Size: 731, Offset: 790},
 		},
-		"devices":     devices,
-		"device_type": "all",
+		SwitchSlotAllowed: true,
+		InstallType:       resources.OTAStreaming,
 	}
 
-	// directly using the SendCommand method
-	apiResponse, err := client.Commands.SendCommand(complexBody)
+	otaResponse, err := client.Commands.InstallOTA(target, update)
 	if err != nil {
 		fmt.Println("Error sending command:", err)
 	} else {
-		fmt.Println("Command sent successfully:", apiResponse.PrettyString())
+		fmt.Println("Command sent successfully:", otaResponse.ID)
 	}
 
 	// Example 2: With validation and convenience methods
 	fmt.Println("\n=== Using Convenience Methods ===")
 
 	// Simple device operations
-	resp, err := client.Commands.Reboot(devices)
+	resp, err := client.Commands.Reboot(target)
 	if err != nil {
 		log.Printf("Reboot failed: %v", err)
 	} else {
@@ -74,7 +68,7 @@ func main() {
 	}
 
 	// Set brightness
-	resp, err = client.Commands.SetBrightness(devices, 70)
+	resp, err = client.Commands.SetBrightness(target, 70)
 	if err != nil {
 		log.Printf("Set brightness failed: %v", err)
 	} else {
@@ -83,7 +77,7 @@ func main() {
 
 	// Send notification
 	resp, err = client.Commands.NotifyDevice(
-		devices,
+		target,
 		"Test Notification",
 		"This is a test message from the SDK",
 		"https://example.com",
@@ -94,23 +88,20 @@ func main() {
 		fmt.Printf("Notification sent successfully\n")
 	}
 
-	// Example 3: Using UpdateDeviceConfig for custom settings
+	// Example 3: Using UpdateCustomSettings for custom settings
 	fmt.Println("\n=== Custom Device Config ===")
 
-	customConfig := map[string]interface{}{
-		"custom_settings_config": map[string]interface{}{
-			"wifi_settings": map[string]interface{}{
-				"ssid":     "Office-WiFi",
-				"security": "WPA2",
-			},
-			"display_settings": map[string]interface{}{
-				"brightness": 80,
-				"timeout":    30000,
-			},
-		},
-	}
-
-	resp, err = client.Commands.UpdateDeviceConfig(devices, customConfig)
+	customConfig := resources.NewCustomSettings().
+		SetSection("wifi_settings", map[string]interface{}{
+			"ssid":     "Office-WiFi",
+			"security": "WPA2",
+		}).
+		SetSection("display_settings", map[string]interface{}{
+			"brightness": 80,
+			"timeout":    30000,
+		})
+
+	resp, err = client.Commands.UpdateCustomSettings(target, resources.DeviceTypeAll, customConfig)
 	if err != nil {
 		log.Printf("Update config failed: %v", err)
 	} else {
@@ -121,7 +112,7 @@ func main() {
 	fmt.Println("\n=== App Management ===")
 
 	// Install an app
-	resp, err = client.Commands.InstallApp(devices, "app-version-id-12345")
+	resp, err = client.Commands.InstallApp(target, "app-version-id-12345")
 	if err != nil {
 		log.Printf("Install app failed: %v", err)
 	} else {
@@ -129,7 +120,7 @@ func main() {
 	}
 
 	// Set kiosk app
-	resp, err = client.Commands.SetKioskApp(devices, "com.example.kiosk")
+	resp, err = client.Commands.SetKioskApp(target, "com.example.kiosk")
 	if err != nil {
 		log.Printf("Set kiosk app failed: %v", err)
 	} else {
@@ -144,7 +135,7 @@ func main() {
 	endTime := time.Now().Add(3 * time.Hour)
 
 	resp, err = client.Commands.ScheduleRebootWindow(
-		devices,
+		target,
 		startTime,
 		endTime,
 		"02:00", // Window starts at 2 AM
@@ -158,7 +149,7 @@ func main() {
 
 	// Schedule recurring notifications
 	resp, err = client.Commands.ScheduleRecurringNotification(
-		devices,
+		target,
 		"Daily Reminder",
 		"Check-in Reminder",
 		"Please complete your daily check-in",
@@ -175,10 +166,10 @@ func main() {
 	// Example 6: Group commands
 	fmt.Println("\n=== Group Commands ===")
 
-	groups := []string{"production-devices", "warehouse-tablets"}
+	groups := resources.GroupTarget("production-devices", "warehouse-tablets")
 
 	// Apply policy to groups
-	resp, err = client.Commands.ApplyPolicyToGroups(
+	resp, err = client.Commands.ApplyPolicy(
 		groups,
 		"https://example.com/policies/production-policy.json",
 	)
@@ -189,7 +180,7 @@ func main() {
 	}
 
 	// Reboot all devices in groups
-	resp, err = client.Commands.RebootGroups(groups)
+	resp, err = client.Commands.Reboot(groups)
 	if err != nil {
 		log.Printf("Reboot groups failed: %v", err)
 	} else {
@@ -200,7 +191,7 @@ func main() {
 	fmt.Println("\n=== Advanced Device Settings ===")
 
 	// Set GPS to high accuracy mode
-	resp, err = client.Commands.SetGPSState(devices, 0) // 0 = High Accuracy
+	resp, err = client.Commands.SetGPSState(target, resources.GPSHighAccuracy)
 	if err != nil {
 		log.Printf("Set GPS failed: %v", err)
 	} else {
@@ -208,7 +199,7 @@ func main() {
 	}
 
 	// Set screen rotation to portrait only
-	resp, err = client.Commands.SetRotationState(devices, 1) // 1 = Portrait Only
+	resp, err = client.Commands.SetRotationState(target, resources.RotationPortrait)
 	if err != nil {
 		log.Printf("Set rotation failed: %v", err)
 	} else {
@@ -216,7 +207,7 @@ func main() {
 	}
 
 	// Set music volume to 50%
-	resp, err = client.Commands.SetVolume(devices, 3, 50) // 3 = Music stream
+	resp, err = client.Commands.SetVolume(target, resources.StreamMusic, 50)
 	if err != nil {
 		log.Printf("Set volume failed: %v", err)
 	} else {
@@ -224,7 +215,7 @@ func main() {
 	}
 
 	// Enable Bluetooth
-	resp, err = client.Commands.SetBluetoothState(devices, true)
+	resp, err = client.Commands.SetBluetoothState(target, true)
 	if err != nil {
 		log.Printf("Set Bluetooth failed: %v", err)
 	} else {
@@ -232,7 +223,7 @@ func main() {
 	}
 
 	// Set device language
-	resp, err = client.Commands.SetDeviceLanguage(devices, "en_US")
+	resp, err = client.Commands.SetDeviceLanguage(target, "en_US")
 	if err != nil {
 		log.Printf("Set language failed: %v", err)
 	} else {
@@ -241,7 +232,7 @@ func main() {
 
 	// Lock device with message
 	resp, err = client.Commands.SetDeviceLockdown(
-		devices,
+		target,
 		true,
 		"Device is under maintenance. Please contact IT support.",
 	)
@@ -252,7 +243,7 @@ func main() {
 	}
 
 	// Capture screenshot with tag
-	resp, err = client.Commands.CaptureScreenshot(devices, "audit-screenshot-2024")
+	resp, err = client.Commands.CaptureScreenshot(target, "audit-screenshot-2024")
 	if err != nil {
 		log.Printf("Capture screenshot failed: %v", err)
 	} else {
@@ -260,7 +251,7 @@ func main() {
 	}
 
 	// Make device beep for 3 seconds
-	resp, err = client.Commands.BeepDevice(devices, "3")
+	resp, err = client.Commands.BeepDevice(target, "3")
 	if err != nil {
 		log.Printf("Beep device failed: %v", err)
 	} else {
@@ -0,0 +1,100 @@
+package requests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// APIError is returned for any response with an HTTP status of 400 or above.
+// Use errors.As to inspect it, or the IsNotFound style helpers for common cases.
+type APIError struct {
+	StatusCode int         // HTTP status code
+	Code       string      // Esper error code, if provided
+	Message    string      // Human readable error message
+	Details    interface{} // Additional error details such as field errors
+	RequestID  string      // Value of the X-Request-Id response header
+	Body       []byte      // Raw response body
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = string(e.Body)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("API error (HTTP %d, %s): %s", e.StatusCode, e.Code, msg)
+	}
+	return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, msg)
+}
+
+// newAPIError builds an APIError from an error response
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       body,
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return apiErr
+	}
+
+	apiErr.Code = firstString(payload, "code", "error_code")
+	apiErr.Message = firstString(payload, "message", "detail", "error")
+	for _, key := range []string{"errors", "details"} {
+		if details, ok := payload[key]; ok {
+			apiErr.Details = details
+			break
+		}
+	}
+	// Field validation errors come back as a plain object keyed by field name
+	if apiErr.Message == "" && apiErr.Details == nil {
+		apiErr.Details = payload
+	}
+	return apiErr
+}
+
+// firstString returns the first non-empty string value found under keys
+func firstString(payload map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch v := payload[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is an APIError with HTTP status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with HTTP status 401,
+// which usually means the token is invalid or expired
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with HTTP status 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is an APIError with HTTP status 429
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
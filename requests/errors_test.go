@@ -0,0 +1,143 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    string
+		wantMessage string
+		wantDetails interface{}
+		wantError   string
+	}{
+		{
+			name:        "code and message",
+			body:        `{"code": "DEVICE_NOT_FOUND", "message": "no such device"}`,
+			wantCode:    "DEVICE_NOT_FOUND",
+			wantMessage: "no such device",
+			wantError:   "API error (HTTP 400, DEVICE_NOT_FOUND): no such device",
+		},
+		{
+			name:        "error_code and detail",
+			body:        `{"error_code": "E42", "detail": "token expired"}`,
+			wantCode:    "E42",
+			wantMessage: "token expired",
+			wantError:   "API error (HTTP 400, E42): token expired",
+		},
+		{
+			name:        "numeric code",
+			body:        `{"code": 1000001, "error": "boom"}`,
+			wantCode:    "1000001",
+			wantMessage: "boom",
+			wantError:   "API error (HTTP 400, 1000001): boom",
+		},
+		{
+			name:        "errors list",
+			body:        `{"message": "invalid", "errors": ["devices is required"]}`,
+			wantMessage: "invalid",
+			wantDetails: []interface{}{"devices is required"},
+			wantError:   "API error (HTTP 400): invalid",
+		},
+		{
+			name:        "details object",
+			body:        `{"message": "invalid", "details": {"field": "devices"}}`,
+			wantMessage: "invalid",
+			wantDetails: map[string]interface{}{"field": "devices"},
+			wantError:   "API error (HTTP 400): invalid",
+		},
+		{
+			name:        "plain field errors",
+			body:        `{"devices": ["This field is required."]}`,
+			wantDetails: map[string]interface{}{"devices": []interface{}{"This field is required."}},
+			wantError:   `API error (HTTP 400): {"devices": ["This field is required."]}`,
+		},
+		{
+			name:      "non JSON body",
+			body:      "<html>Bad Gateway</html>",
+			wantError: "API error (HTTP 400): <html>Bad Gateway</html>",
+		},
+		{
+			name:      "empty body",
+			body:      "",
+			wantError: "API error (HTTP 400): ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{"X-Request-Id": {"req-1"}}}
+			apiErr := newAPIError(resp, []byte(tt.body))
+
+			if apiErr.Code != tt.wantCode || apiErr.Message != tt.wantMessage {
+				t.Errorf("code, message = %q, %q, want %q, %q", apiErr.Code, apiErr.Message, tt.wantCode, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(apiErr.Details, tt.wantDetails) {
+				t.Errorf("details = %#v, want %#v", apiErr.Details, tt.wantDetails)
+			}
+			if apiErr.RequestID != "req-1" || string(apiErr.Body) != tt.body {
+				t.Errorf("request ID, body = %q, %q", apiErr.RequestID, apiErr.Body)
+			}
+			if apiErr.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", apiErr.Error(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestStatusHelpers(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusUnauthorized, IsUnauthorized},
+		{http.StatusForbidden, IsForbidden},
+		{http.StatusTooManyRequests, IsRateLimited},
+	}
+	helpers := []func(error) bool{IsNotFound, IsUnauthorized, IsForbidden, IsRateLimited}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server, _ := statusServer(t, nil, tt.status)
+			err := testRequest(server.URL, fastPolicy(2)).Do(context.Background(), http.MethodGet, "/", nil, nil, nil)
+			wrapped := fmt.Errorf("listing devices: %w", err)
+
+			if !tt.check(wrapped) {
+				t.Errorf("helper did not match wrapped error %v", wrapped)
+			}
+			matches := 0
+			for _, helper := range helpers {
+				if helper(wrapped) {
+					matches++
+				}
+			}
+			if matches != 1 {
+				t.Errorf("%d helpers matched, want 1", matches)
+			}
+		})
+	}
+
+	t.Run("wrapped by an interrupted retry", func(t *testing.T) {
+		server, _ := statusServer(t, nil, http.StatusTooManyRequests)
+		policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Second}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := testRequest(server.URL, policy).Do(ctx, http.MethodGet, "/", nil, nil, nil)
+		if !errors.Is(err, context.DeadlineExceeded) || !IsRateLimited(err) {
+			t.Errorf("error = %v, want both the deadline and the rate limit", err)
+		}
+	})
+
+	if IsNotFound(errors.New("not found")) || IsNotFound(nil) {
+		t.Error("IsNotFound matched a non-API error")
+	}
+}
@@ -2,6 +2,7 @@ package requests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ type Request struct {
 	EnterpriseID string
 	Auth         Auth
 	HTTPClient   *http.Client
+	Retry        *RetryPolicy // nil disables retries
 }
 
 type Auth struct {
@@ -22,6 +24,7 @@ type Auth struct {
 
 type APIResponse struct {
 	Data map[string]interface{}
+	Raw  json.RawMessage // Undecoded response body
 }
 
 func (r *APIResponse) PrettyString() string {
@@ -42,68 +45,154 @@ func (r *APIResponse) Get() map[string]interface{} {
 	return r.Data
 }
 
+// UnmarshalJSON keeps the raw body and decodes it into Data
+func (r *APIResponse) UnmarshalJSON(data []byte) error {
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	r.Data = result
+	r.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Decode unmarshals the raw response body into v
+func (r *APIResponse) Decode(v interface{}) error {
+	if err := json.Unmarshal(r.Raw, v); err != nil {
+		return fmt.Errorf("failed to decode response JSON: %w", err)
+	}
+	return nil
+}
+
+// Post sends a POST request with a JSON body to the given endpoint
 func (request *Request) Post(endpoint string, requestBody map[string]interface{}) (*APIResponse, error) {
+	return request.PostContext(context.Background(), endpoint, requestBody)
+}
+
+// PostContext is like Post but carries ctx for cancellation and deadlines
+func (request *Request) PostContext(ctx context.Context, endpoint string, requestBody map[string]interface{}) (*APIResponse, error) {
+	return request.doResponse(ctx, http.MethodPost, endpoint, nil, requestBody)
+}
+
+// Get sends a GET request with optional query parameters to the given endpoint
+func (request *Request) Get(endpoint string, queryParam url.Values) (*APIResponse, error) {
+	return request.GetContext(context.Background(), endpoint, queryParam)
+}
+
+// GetContext is like Get but carries ctx for cancellation and deadlines
+func (request *Request) GetContext(ctx context.Context, endpoint string, queryParam url.Values) (*APIResponse, error) {
+	return request.doResponse(ctx, http.MethodGet, endpoint, queryParam, nil)
+}
+
+// Put sends a PUT request replacing the resource at endpoint with requestBody
+func (request *Request) Put(endpoint string, requestBody interface{}) (*APIResponse, error) {
+	return request.PutContext(context.Background(), endpoint, requestBody)
+}
+
+// PutContext is like Put but carries ctx for cancellation and deadlines
+func (request *Request) PutContext(ctx context.Context, endpoint string, requestBody interface{}) (*APIResponse, error) {
+	return request.doResponse(ctx, http.MethodPut, endpoint, nil, requestBody)
+}
+
+// Patch sends a PATCH request partially updating the resource at endpoint
+func (request *Request) Patch(endpoint string, requestBody interface{}) (*APIResponse, error) {
+	return request.PatchContext(context.Background(), endpoint, requestBody)
+}
+
+// PatchContext is like Patch but carries ctx for cancellation and deadlines
+func (request *Request) PatchContext(ctx context.Context, endpoint string, requestBody interface{}) (*APIResponse, error) {
+	return request.doResponse(ctx, http.MethodPatch, endpoint, nil, requestBody)
+}
+
+// Delete sends a DELETE request for the resource at endpoint.
+// Responses without a body yield an empty APIResponse.
+func (request *Request) Delete(endpoint string) (*APIResponse, error) {
+	return request.DeleteContext(context.Background(), endpoint)
+}
+
+// DeleteContext is like Delete but carries ctx for cancellation and deadlines
+func (request *Request) DeleteContext(ctx context.Context, endpoint string) (*APIResponse, error) {
+	return request.doResponse(ctx, http.MethodDelete, endpoint, nil, nil)
+}
+
+// Do is the core of every request. It sends method to endpoint with the
+// optional query parameters and JSON encoded requestBody, then decodes the
+// JSON response into out. out may be any value accepted by json.Unmarshal,
+// including types implementing json.Unmarshaler; pass nil to discard the
+// response. Empty responses, such as 204 No Content, leave out untouched.
+func (request *Request) Do(ctx context.Context, method, endpoint string, queryParam url.Values, requestBody interface{}, out interface{}) error {
 	fullURL := request.BaseURL + endpoint
+	if len(queryParam) > 0 {
+		fullURL += "?" + queryParam.Encode()
+	}
 
 	// Convert body to JSON with error handling
-	jsonData, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	var jsonData []byte
+	if requestBody != nil {
+		var err error
+		if jsonData, err = json.Marshal(requestBody); err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
 	}
 
-	// Create request
-	req, err := http.NewRequest("POST", fullURL, bytes.NewBuffer(jsonData))
+	responseBody, err := request.send(ctx, method, fullURL, jsonData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	// Add headers
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", request.Auth.Token))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("X-Caller-Id", "Esper-sdk")
-	req.Header.Add("X-Tenant-Id", request.EnterpriseID)
-
-	// Make the request
-	resp, err := request.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	// Parse JSON response
+	if out == nil || len(bytes.TrimSpace(responseBody)) == 0 {
+		return nil
 	}
-	defer resp.Body.Close()
-
-	// Read response body
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if err = json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("failed to parse response JSON: %w", err)
 	}
+	return nil
+}
 
-	// Handle error responses
-	if resp.StatusCode >= 400 {
-		var errorResp map[string]interface{}
-		if err = json.Unmarshal(responseBody, &errorResp); err != nil {
-			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(responseBody))
-		}
-		return nil, fmt.Errorf("API error (HTTP %d): %v", resp.StatusCode, errorResp)
+// doResponse runs Do and wraps the result in an APIResponse
+func (request *Request) doResponse(ctx context.Context, method, endpoint string, queryParam url.Values, requestBody interface{}) (*APIResponse, error) {
+	var result APIResponse
+	if err := request.Do(ctx, method, endpoint, queryParam, requestBody, &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	// Parse JSON response
-	var result map[string]interface{}
-	if err = json.Unmarshal(responseBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response JSON: %w", err)
+// send performs the HTTP exchange, retrying according to the retry policy,
+// and returns the body of a successful response
+func (request *Request) send(ctx context.Context, method, fullURL string, body []byte) ([]byte, error) {
+	policy := request.Retry
+	for attempt := 1; ; attempt++ {
+		responseBody, resp, err := request.sendOnce(ctx, method, fullURL, body)
+		if err == nil {
+			return responseBody, nil
+		}
+		if !policy.shouldRetry(ctx, method, attempt, resp, err) {
+			return nil, err
+		}
+		delay, ok := policy.backoff(attempt, resp)
+		if !ok {
+			return nil, err
+		}
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			return nil, fmt.Errorf("%w (last attempt: %w)", waitErr, err)
+		}
 	}
-
-	return &APIResponse{Data: result}, nil
 }
 
-func (request *Request) Get(endpoint string, queryParam url.Values) (*APIResponse, error) {
-	fullURL := request.BaseURL + endpoint
-	if queryParam != nil && len(queryParam) > 0 {
-		fullURL += "?" + queryParam.Encode()
+// sendOnce performs a single HTTP exchange. The response is returned alongside
+// any error so the caller can inspect its status code and headers.
+func (request *Request) sendOnce(ctx context.Context, method, fullURL string, body []byte) ([]byte, *http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 
 	// Create request
-	req, err := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add headers
@@ -115,30 +204,20 @@ func (request *Request) Get(endpoint string, queryParam url.Values) (*APIRespons
 	// Make the request
 	resp, err := request.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Handle error responses
 	if resp.StatusCode >= 400 {
-		var errorResp map[string]interface{}
-		if err = json.Unmarshal(responseBody, &errorResp); err != nil {
-			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(responseBody))
-		}
-		return nil, fmt.Errorf("API error (HTTP %d): %v", resp.StatusCode, errorResp)
-	}
-
-	// Parse JSON response
-	var result map[string]interface{}
-	if err = json.Unmarshal(responseBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response JSON: %w", err)
+		return nil, resp, newAPIError(resp, responseBody)
 	}
 
-	return &APIResponse{Data: result}, nil
+	return responseBody, resp, nil
 }
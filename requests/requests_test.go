@@ -0,0 +1,180 @@
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// recorded is what echoServer saw of the last request
+type recorded struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+// echoServer records every request and answers with status and body
+func echoServer(t *testing.T, status int, body string) (*httptest.Server, *recorded) {
+	t.Helper()
+	last := &recorded{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		*last = recorded{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header, Body: string(data)}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, last
+}
+
+func TestVerbs(t *testing.T) {
+	tests := []struct {
+		name       string
+		call       func(r *Request) (*APIResponse, error)
+		wantMethod string
+		wantBody   string
+		wantQuery  string
+	}{
+		{"Get", func(r *Request) (*APIResponse, error) {
+			return r.Get("/devices", url.Values{"limit": {"5"}})
+		}, http.MethodGet, "", "limit=5"},
+		{"Post", func(r *Request) (*APIResponse, error) {
+			return r.Post("/devices", map[string]interface{}{"name": "a"})
+		}, http.MethodPost, `{"name":"a"}`, ""},
+		{"Put", func(r *Request) (*APIResponse, error) {
+			return r.Put("/devices", struct {
+				Name string `json:"name"`
+			}{"b"})
+		}, http.MethodPut, `{"name":"b"}`, ""},
+		{"Patch", func(r *Request) (*APIResponse, error) {
+			return r.Patch("/devices", map[string][]string{"tags": {}})
+		}, http.MethodPatch, `{"tags":[]}`, ""},
+		{"Delete", func(r *Request) (*APIResponse, error) {
+			return r.Delete("/devices")
+		}, http.MethodDelete, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, last := echoServer(t, http.StatusOK, `{"id": "d1", "count": 2}`)
+			resp, err := tt.call(testRequest(server.URL, nil))
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+
+			if last.Method != tt.wantMethod || last.Path != "/devices" || last.Body != tt.wantBody || last.Query.Encode() != tt.wantQuery {
+				t.Errorf("server saw %+v", last)
+			}
+			for header, want := range map[string]string{
+				"Authorization": "Bearer token",
+				"Content-Type":  "application/json",
+				"X-Tenant-Id":   "enterprise",
+			} {
+				if got := last.Header.Get(header); got != want {
+					t.Errorf("%s header = %q, want %q", header, got, want)
+				}
+			}
+			if resp.Get()["id"] != "d1" || string(resp.Raw) != `{"id": "d1", "count": 2}` {
+				t.Errorf("response = %v, raw %s", resp.Data, resp.Raw)
+			}
+		})
+	}
+}
+
+func TestDoEmptyResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"no content", http.StatusNoContent, ""},
+		{"whitespace only", http.StatusOK, " \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := echoServer(t, tt.status, tt.body)
+			request := testRequest(server.URL, nil)
+
+			out := map[string]string{"untouched": "yes"}
+			if err := request.Do(context.Background(), http.MethodDelete, "/", nil, nil, &out); err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if out["untouched"] != "yes" {
+				t.Errorf("out was modified: %v", out)
+			}
+
+			resp, err := request.Delete("/")
+			if err != nil || resp == nil || resp.Data != nil {
+				t.Errorf("Delete() = %v, %v, want an empty response", resp, err)
+			}
+		})
+	}
+}
+
+func TestDoDecoding(t *testing.T) {
+	server, _ := echoServer(t, http.StatusOK, `{"id": "d1", "tags": ["a"], "extra": true}`)
+	request := testRequest(server.URL, nil)
+
+	t.Run("into a struct", func(t *testing.T) {
+		var device struct {
+			ID   string   `json:"id"`
+			Tags []string `json:"tags"`
+		}
+		if err := request.Do(context.Background(), http.MethodGet, "/", nil, nil, &device); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		if device.ID != "d1" || len(device.Tags) != 1 {
+			t.Errorf("decoded %+v", device)
+		}
+	})
+
+	t.Run("nil out discards the body", func(t *testing.T) {
+		if err := request.Do(context.Background(), http.MethodGet, "/", nil, nil, nil); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	})
+
+	t.Run("mismatched type", func(t *testing.T) {
+		var ids []string
+		if err := request.Do(context.Background(), http.MethodGet, "/", nil, nil, &ids); err == nil {
+			t.Error("Do() decoded an object into a slice")
+		}
+	})
+
+	t.Run("unmarshalable body", func(t *testing.T) {
+		if err := request.Do(context.Background(), http.MethodPost, "/", nil, make(chan int), nil); err == nil {
+			t.Error("Do() sent a body that cannot be marshalled")
+		}
+	})
+}
+
+func TestAPIResponse(t *testing.T) {
+	raw := `{"id": "d1", "battery": {"level": 80}}`
+	var resp APIResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if resp.Data["id"] != "d1" || string(resp.Raw) != raw {
+		t.Errorf("Data = %v, Raw = %s", resp.Data, resp.Raw)
+	}
+
+	var battery struct {
+		Battery struct {
+			Level int `json:"level"`
+		} `json:"battery"`
+	}
+	if err := resp.Decode(&battery); err != nil || battery.Battery.Level != 80 {
+		t.Errorf("Decode() = %+v, %v", battery, err)
+	}
+	if err := resp.Decode(&[]string{}); err == nil {
+		t.Error("Decode() into a mismatched type succeeded")
+	}
+	if err := json.Unmarshal([]byte(`[1, 2]`), &resp); err == nil {
+		t.Error("Unmarshal() accepted a non-object body")
+	}
+}
@@ -0,0 +1,132 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+// Network errors and 429/5xx responses are considered transient. A Retry-After
+// header asking for a longer wait than MaxBackoff ends the retries.
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first one; values <= 1 disable retries
+	InitialBackoff time.Duration // Delay before the first retry
+	MaxBackoff     time.Duration // Upper bound for the exponential delay and for Retry-After waits
+	Jitter         float64       // Fraction (0-1) of each delay that is randomised
+
+	// RetryNonIdempotent also retries POST and PATCH requests, such as
+	// command submissions. Only enable this if duplicate commands are acceptable.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by NewClient: up to 4 attempts of
+// idempotent requests with exponential backoff from 500ms to 30s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// shouldRetry reports whether a failed attempt may be retried
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !isIdempotent(method) && !p.RetryNonIdempotent {
+		return false
+	}
+	if resp == nil {
+		return isTransportError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransportError reports whether err is a network failure while talking to
+// the server, e.g. connection refused, reset or a timeout. Failures to build
+// the request, such as a malformed URL, are not worth retrying.
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || urlErr.Op == "parse" {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr) ||
+		errors.Is(urlErr.Err, io.EOF) ||
+		errors.Is(urlErr.Err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay before the next attempt. A Retry-After header on
+// 429 and 503 responses takes precedence over the exponential delay; ok is
+// false when it asks for a longer wait than MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (delay time.Duration, ok bool) {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if delay, found := parseRetryAfter(resp.Header.Get("Retry-After")); found {
+			return delay, p.MaxBackoff <= 0 || delay <= p.MaxBackoff
+		}
+	}
+
+	delay = p.InitialBackoff << (attempt - 1)
+	if delay <= 0 || (p.MaxBackoff > 0 && delay > p.MaxBackoff) {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 && delay > 0 {
+		spread := float64(delay) * p.Jitter
+		delay += time.Duration(spread*2*rand.Float64() - spread)
+	}
+	return delay, true
+}
+
+// parseRetryAfter parses a Retry-After value given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return max(time.Until(when), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRequest(baseURL string, policy *RetryPolicy) *Request {
+	return &Request{
+		BaseURL:      baseURL,
+		EnterpriseID: "enterprise",
+		Auth:         Auth{Token: "token"},
+		HTTPClient:   &http.Client{Timeout: 5 * time.Second},
+		Retry:        policy,
+	}
+}
+
+func fastPolicy(attempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    attempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+}
+
+// statusServer answers with the given statuses in order, repeating the last one
+func statusServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		status := statuses[min(n, len(statuses))-1]
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryAttempts(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		policy    *RetryPolicy
+		statuses  []int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"success", http.MethodGet, fastPolicy(3), []int{200}, 1, false},
+		{"recovers after 503", http.MethodGet, fastPolicy(3), []int{503, 502, 200}, 3, false},
+		{"gives up after max attempts", http.MethodGet, fastPolicy(3), []int{500}, 3, true},
+		{"429 is retried", http.MethodDelete, fastPolicy(2), []int{429, 200}, 2, false},
+		{"4xx is not retried", http.MethodGet, fastPolicy(3), []int{404}, 1, true},
+		{"POST is not retried by default", http.MethodPost, fastPolicy(3), []int{503}, 1, true},
+		{"POST is retried when opted in", http.MethodPost, &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}, []int{503, 200}, 2, false},
+		{"nil policy disables retries", http.MethodGet, nil, []int{503}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := statusServer(t, nil, tt.statuses...)
+			err := testRequest(server.URL, tt.policy).Do(context.Background(), tt.method, "/", nil, nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		wantCalls  int32
+		minElapsed time.Duration
+	}{
+		{"honoured", "1", 2, time.Second},
+		{"longer than MaxBackoff gives up", "86400", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{"Retry-After": {tt.retryAfter}}
+			server, calls := statusServer(t, header, http.StatusTooManyRequests, http.StatusOK)
+			policy := &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+
+			start := time.Now()
+			testRequest(server.URL, policy).Do(context.Background(), http.MethodGet, "/", nil, nil, nil)
+			elapsed := time.Since(start)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server called %d times, want %d", got, tt.wantCalls)
+			}
+			if elapsed < tt.minElapsed {
+				t.Errorf("retried after %v, want at least %v", elapsed, tt.minElapsed)
+			}
+		})
+	}
+}
+
+func TestRetryReturnsContextErrorDuringBackoff(t *testing.T) {
+	server, _ := statusServer(t, nil, http.StatusServiceUnavailable)
+	policy := &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: time.Second}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := testRequest(server.URL, policy).Do(ctx, http.MethodGet, "/", nil, nil, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Do() error = %v, want context.DeadlineExceeded", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Do() error = %v, want it to carry the last APIError", err)
+	}
+}
+
+func TestRetrySkipsRequestBuildErrors(t *testing.T) {
+	start := time.Now()
+	err := testRequest("http://[::1", &RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond}).
+		Do(context.Background(), http.MethodGet, "/", nil, nil, nil)
+	if err == nil {
+		t.Fatal("Do() with a malformed URL succeeded")
+	}
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		t.Errorf("malformed URL was retried, took %v", elapsed)
+	}
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	var attempts int
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	request := testRequest(url, policy)
+	for attempt := 1; ; attempt++ {
+		attempts = attempt
+		_, resp, err := request.sendOnce(context.Background(), http.MethodGet, url, nil)
+		if !policy.shouldRetry(context.Background(), http.MethodGet, attempt, resp, err) {
+			break
+		}
+	}
+	if attempts != 3 {
+		t.Errorf("connection refused retried %d times, want 3", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
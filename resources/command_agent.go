@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Bounds for the interval at which the agent reports a heartbeat
+const (
+	MinHeartbeatInterval = time.Minute
+	MaxHeartbeatInterval = 24 * time.Hour
+)
+
+// UpdateHeartbeat asks the agent on the target devices to report a heartbeat
+// now. A non-zero interval also changes how often heartbeats are reported; it
+// must be a whole number of seconds between MinHeartbeatInterval and MaxHeartbeatInterval.
+func (c *Commands) UpdateHeartbeat(target Target, interval time.Duration) (*CommandResponse, error) {
+	return c.UpdateHeartbeatContext(context.Background(), target, interval)
+}
+
+// UpdateHeartbeatContext is like UpdateHeartbeat but carries ctx for cancellation and deadlines
+func (c *Commands) UpdateHeartbeatContext(ctx context.Context, target Target, interval time.Duration) (*CommandResponse, error) {
+	var args map[string]interface{}
+	if interval != 0 {
+		if interval < MinHeartbeatInterval || interval > MaxHeartbeatInterval {
+			return nil, fmt.Errorf("heartbeat interval must be between %s and %s", MinHeartbeatInterval, MaxHeartbeatInterval)
+		}
+		if interval%time.Second != 0 {
+			return nil, fmt.Errorf("heartbeat interval must be a whole number of seconds")
+		}
+		args = map[string]interface{}{
+			"heartbeat_interval": int(interval / time.Second),
+		}
+	}
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandUpdateHeartbeat, args))
+}
+
+// UpdateLatestDPC updates the Esper agent on the target devices to the latest version
+func (c *Commands) UpdateLatestDPC(target Target) (*CommandResponse, error) {
+	return c.UpdateLatestDPCContext(context.Background(), target)
+}
+
+// UpdateLatestDPCContext is like UpdateLatestDPC but carries ctx for cancellation and deadlines
+func (c *Commands) UpdateLatestDPCContext(ctx context.Context, target Target) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandUpdateLatestDPC, nil))
+}
+
+// Converge reapplies the assigned configuration on the target devices
+func (c *Commands) Converge(target Target) (*CommandResponse, error) {
+	return c.ConvergeContext(context.Background(), target)
+}
+
+// ConvergeContext is like Converge but carries ctx for cancellation and deadlines
+func (c *Commands) ConvergeContext(ctx context.Context, target Target) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandConverge, nil))
+}
+
+// SelfHealResult holds the outcome of SelfHeal
+type SelfHealResult struct {
+	Converge  *CommandResult   // Final per-device statuses of the converge command
+	Heartbeat *CommandResponse // The heartbeat command sent afterwards, nil if no device converged
+}
+
+// SelfHeal brings devices that have fallen out of compliance back in line:
+// it converges the target, waits for the converge command to finish and then
+// requests a fresh heartbeat from the devices that converged successfully so
+// the console reflects their new state. Both commands are sent immediately,
+// even on a Commands returned by WithSchedule. See WaitForCompletion for why
+// ctx should carry a deadline.
+func (c *Commands) SelfHeal(ctx context.Context, target Target, opts *WaitOptions) (*SelfHealResult, error) {
+	immediate := *c
+	immediate.schedule = nil
+
+	converge, err := immediate.ConvergeContext(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("converge failed: %w", err)
+	}
+
+	result, err := immediate.WaitForCompletion(ctx, converge.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	converged := result.Succeeded()
+	if len(converged) == 0 {
+		return &SelfHealResult{Converge: result}, nil
+	}
+	heartbeat, err := immediate.UpdateHeartbeatContext(ctx, DeviceTarget(converged...), 0)
+	if err != nil {
+		return &SelfHealResult{Converge: result}, fmt.Errorf("heartbeat update failed: %w", err)
+	}
+	return &SelfHealResult{Converge: result, Heartbeat: heartbeat}, nil
+}
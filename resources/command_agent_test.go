@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelfHealHeartbeatsConvergedDevicesOnly(t *testing.T) {
+	var sent []CommandRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			var req CommandRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sent = append(sent, req)
+			json.NewEncoder(w).Encode(map[string]string{"id": string(req.Command)})
+		case strings.HasSuffix(r.URL.Path, "/status/"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": []CommandStatus{
+				{Device: "d1", State: CommandStateSuccess},
+				{Device: "d2", State: CommandStateTimeout},
+			}})
+		default:
+			json.NewEncoder(w).Encode(map[string]string{"id": "CONVERGE"})
+		}
+	}))
+	defer server.Close()
+
+	schedule := RecurringSchedule("nightly", time.Now(), time.Now().Add(24*time.Hour), EveryDay, nil, TimeTypeConsole)
+	commands := (&Commands{Request: testRequest(server.URL)}).WithSchedule(schedule)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := commands.SelfHeal(ctx, GroupTarget("g1"), &WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("SelfHeal() error = %v", err)
+	}
+	if result.Heartbeat == nil {
+		t.Fatal("SelfHeal() sent no heartbeat")
+	}
+	if len(sent) != 2 {
+		t.Fatalf("sent %d commands, want 2", len(sent))
+	}
+	for _, req := range sent {
+		if req.Schedule != "" && req.Schedule != ScheduleImmediate {
+			t.Errorf("%s was sent with schedule %s", req.Command, req.Schedule)
+		}
+	}
+	if heartbeat := sent[1]; heartbeat.CommandType != CommandTypeDevice || !slices.Equal(heartbeat.Devices, []string{"d1"}) {
+		t.Errorf("heartbeat targeted %s %v, want DEVICE [d1]", heartbeat.CommandType, heartbeat.Devices)
+	}
+}
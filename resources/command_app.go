@@ -0,0 +1,160 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AppPermission is an Android runtime permission
+type AppPermission string
+
+const (
+	PermissionCamera                   AppPermission = "android.permission.CAMERA"
+	PermissionRecordAudio              AppPermission = "android.permission.RECORD_AUDIO"
+	PermissionAccessFineLocation       AppPermission = "android.permission.ACCESS_FINE_LOCATION"
+	PermissionAccessCoarseLocation     AppPermission = "android.permission.ACCESS_COARSE_LOCATION"
+	PermissionAccessBackgroundLocation AppPermission = "android.permission.ACCESS_BACKGROUND_LOCATION"
+	PermissionReadContacts             AppPermission = "android.permission.READ_CONTACTS"
+	PermissionWriteContacts            AppPermission = "android.permission.WRITE_CONTACTS"
+	PermissionGetAccounts              AppPermission = "android.permission.GET_ACCOUNTS"
+	PermissionReadCalendar             AppPermission = "android.permission.READ_CALENDAR"
+	PermissionWriteCalendar            AppPermission = "android.permission.WRITE_CALENDAR"
+	PermissionReadPhoneState           AppPermission = "android.permission.READ_PHONE_STATE"
+	PermissionCallPhone                AppPermission = "android.permission.CALL_PHONE"
+	PermissionReadCallLog              AppPermission = "android.permission.READ_CALL_LOG"
+	PermissionWriteCallLog             AppPermission = "android.permission.WRITE_CALL_LOG"
+	PermissionSendSMS                  AppPermission = "android.permission.SEND_SMS"
+	PermissionReceiveSMS               AppPermission = "android.permission.RECEIVE_SMS"
+	PermissionReadSMS                  AppPermission = "android.permission.READ_SMS"
+	PermissionReadExternalStorage      AppPermission = "android.permission.READ_EXTERNAL_STORAGE"
+	PermissionWriteExternalStorage     AppPermission = "android.permission.WRITE_EXTERNAL_STORAGE"
+	PermissionReadMediaImages          AppPermission = "android.permission.READ_MEDIA_IMAGES"
+	PermissionReadMediaVideo           AppPermission = "android.permission.READ_MEDIA_VIDEO"
+	PermissionReadMediaAudio           AppPermission = "android.permission.READ_MEDIA_AUDIO"
+	PermissionBodySensors              AppPermission = "android.permission.BODY_SENSORS"
+	PermissionActivityRecognition      AppPermission = "android.permission.ACTIVITY_RECOGNITION"
+	PermissionPostNotifications        AppPermission = "android.permission.POST_NOTIFICATIONS"
+	PermissionBluetoothScan            AppPermission = "android.permission.BLUETOOTH_SCAN"
+	PermissionBluetoothConnect         AppPermission = "android.permission.BLUETOOTH_CONNECT"
+	PermissionNearbyWifiDevices        AppPermission = "android.permission.NEARBY_WIFI_DEVICES"
+)
+
+// PermissionGrant is the grant state applied to a runtime permission
+type PermissionGrant string
+
+const (
+	PermissionGrantAllow  PermissionGrant = "GRANT"  // Grant the permission silently
+	PermissionGrantDeny   PermissionGrant = "DENY"   // Deny the permission silently
+	PermissionGrantPrompt PermissionGrant = "PROMPT" // Let the user decide
+)
+
+// NotificationState controls whether an app may post notifications
+type NotificationState string
+
+const (
+	NotificationsEnabled  NotificationState = "ENABLE"
+	NotificationsDisabled NotificationState = "DISABLE"
+)
+
+// packageNamePattern matches Java style Android package names such as com.example.app
+var packageNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+$`)
+
+// validatePackageName checks that name is a well formed Android package name
+func validatePackageName(name string) error {
+	if !packageNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid package name %q", name)
+	}
+	return nil
+}
+
+// AddToWhitelist allows the given packages to run on the target devices
+func (c *Commands) AddToWhitelist(target Target, packageNames ...string) (*CommandResponse, error) {
+	return c.AddToWhitelistContext(context.Background(), target, packageNames...)
+}
+
+// AddToWhitelistContext is like AddToWhitelist but carries ctx for cancellation and deadlines
+func (c *Commands) AddToWhitelistContext(ctx context.Context, target Target, packageNames ...string) (*CommandResponse, error) {
+	args, err := whitelistArgs(packageNames)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandAddToWhitelist, args))
+}
+
+// RemoveFromWhitelist removes the given packages from the whitelist of the target devices
+func (c *Commands) RemoveFromWhitelist(target Target, packageNames ...string) (*CommandResponse, error) {
+	return c.RemoveFromWhitelistContext(context.Background(), target, packageNames...)
+}
+
+// RemoveFromWhitelistContext is like RemoveFromWhitelist but carries ctx for cancellation and deadlines
+func (c *Commands) RemoveFromWhitelistContext(ctx context.Context, target Target, packageNames ...string) (*CommandResponse, error) {
+	args, err := whitelistArgs(packageNames)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandRemoveFromWhitelist, args))
+}
+
+func whitelistArgs(packageNames []string) (map[string]interface{}, error) {
+	if len(packageNames) == 0 {
+		return nil, errors.New("at least one package name is required")
+	}
+	for _, name := range packageNames {
+		if err := validatePackageName(name); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]interface{}{"package_names": packageNames}, nil
+}
+
+// SetAppPermission grants, denies or prompts for a runtime permission of an app
+func (c *Commands) SetAppPermission(target Target, packageName string, permission AppPermission, grant PermissionGrant) (*CommandResponse, error) {
+	return c.SetAppPermissionContext(context.Background(), target, packageName, permission, grant)
+}
+
+// SetAppPermissionContext is like SetAppPermission but carries ctx for cancellation and deadlines
+func (c *Commands) SetAppPermissionContext(ctx context.Context, target Target, packageName string, permission AppPermission, grant PermissionGrant) (*CommandResponse, error) {
+	if err := validatePackageName(packageName); err != nil {
+		return nil, err
+	}
+	// Apps may declare their own runtime permissions, so only the shape is checked
+	if !strings.Contains(string(permission), ".") {
+		return nil, fmt.Errorf("invalid permission %q", permission)
+	}
+	switch grant {
+	case PermissionGrantAllow, PermissionGrantDeny, PermissionGrantPrompt:
+	default:
+		return nil, fmt.Errorf("unknown permission grant %q", grant)
+	}
+
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetAppPermission, map[string]interface{}{
+		"package_name": packageName,
+		"permission":   string(permission),
+		"grant_state":  string(grant),
+	}))
+}
+
+// SetAppNotifications enables or disables notifications of an app
+func (c *Commands) SetAppNotifications(target Target, packageName string, state NotificationState) (*CommandResponse, error) {
+	return c.SetAppNotificationsContext(context.Background(), target, packageName, state)
+}
+
+// SetAppNotificationsContext is like SetAppNotifications but carries ctx for cancellation and deadlines
+func (c *Commands) SetAppNotificationsContext(ctx context.Context, target Target, packageName string, state NotificationState) (*CommandResponse, error) {
+	if err := validatePackageName(packageName); err != nil {
+		return nil, err
+	}
+	switch state {
+	case NotificationsEnabled, NotificationsDisabled:
+	default:
+		return nil, fmt.Errorf("unknown notification state %q", state)
+	}
+
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetAppNotifications, map[string]interface{}{
+		"package_name":       packageName,
+		"notification_state": string(state),
+	}))
+}
@@ -0,0 +1,173 @@
+package resources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidatePackageName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"com.example.app", true},
+		{"io.esper.agent_v2", true},
+		{"Com.Example", true},
+		{"", false},
+		{"example", false},
+		{"com..example", false},
+		{"com.example.", false},
+		{"1com.example", false},
+		{"com.1example", false},
+		{"com.exa-mple", false},
+		{"com.example app", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePackageName(tt.name); (err == nil) != tt.valid {
+				t.Errorf("validatePackageName(%q) error = %v, want valid %v", tt.name, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestAppHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		send     func(c *Commands) error
+		wantCmd  Command
+		wantArgs map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name: "whitelist",
+			send: func(c *Commands) error {
+				_, err := c.AddToWhitelist(DeviceTarget("d"), "com.a.b", "com.c.d")
+				return err
+			},
+			wantCmd:  CommandAddToWhitelist,
+			wantArgs: map[string]interface{}{"package_names": []interface{}{"com.a.b", "com.c.d"}},
+		},
+		{
+			name: "remove from whitelist",
+			send: func(c *Commands) error {
+				_, err := c.RemoveFromWhitelist(DeviceTarget("d"), "com.a.b")
+				return err
+			},
+			wantCmd:  CommandRemoveFromWhitelist,
+			wantArgs: map[string]interface{}{"package_names": []interface{}{"com.a.b"}},
+		},
+		{
+			name: "empty package list",
+			send: func(c *Commands) error {
+				_, err := c.AddToWhitelist(DeviceTarget("d"))
+				return err
+			},
+			wantErr: "at least one package name",
+		},
+		{
+			name: "invalid package in list",
+			send: func(c *Commands) error {
+				_, err := c.RemoveFromWhitelist(DeviceTarget("d"), "com.a.b", "bad")
+				return err
+			},
+			wantErr: `invalid package name "bad"`,
+		},
+		{
+			name: "permission",
+			send: func(c *Commands) error {
+				_, err := c.SetAppPermission(DeviceTarget("d"), "com.a.b", PermissionCamera, PermissionGrantDeny)
+				return err
+			},
+			wantCmd: CommandSetAppPermission,
+			wantArgs: map[string]interface{}{
+				"package_name": "com.a.b",
+				"permission":   "android.permission.CAMERA",
+				"grant_state":  "DENY",
+			},
+		},
+		{
+			name: "custom permission",
+			send: func(c *Commands) error {
+				_, err := c.SetAppPermission(DeviceTarget("d"), "com.a.b", "com.a.b.permission.SYNC", PermissionGrantPrompt)
+				return err
+			},
+			wantCmd: CommandSetAppPermission,
+			wantArgs: map[string]interface{}{
+				"package_name": "com.a.b",
+				"permission":   "com.a.b.permission.SYNC",
+				"grant_state":  "PROMPT",
+			},
+		},
+		{
+			name: "malformed permission",
+			send: func(c *Commands) error {
+				_, err := c.SetAppPermission(DeviceTarget("d"), "com.a.b", "CAMERA", PermissionGrantAllow)
+				return err
+			},
+			wantErr: `invalid permission "CAMERA"`,
+		},
+		{
+			name: "unknown grant",
+			send: func(c *Commands) error {
+				_, err := c.SetAppPermission(DeviceTarget("d"), "com.a.b", PermissionCamera, "ALLOW")
+				return err
+			},
+			wantErr: `unknown permission grant "ALLOW"`,
+		},
+		{
+			name: "notifications",
+			send: func(c *Commands) error {
+				_, err := c.SetAppNotifications(GroupTarget("g"), "com.a.b", NotificationsDisabled)
+				return err
+			},
+			wantCmd: CommandSetAppNotifications,
+			wantArgs: map[string]interface{}{
+				"package_name":       "com.a.b",
+				"notification_state": "DISABLE",
+			},
+		},
+		{
+			name: "unknown notification state",
+			send: func(c *Commands) error {
+				_, err := c.SetAppNotifications(DeviceTarget("d"), "com.a.b", "OFF")
+				return err
+			},
+			wantErr: `unknown notification state "OFF"`,
+		},
+		{
+			name: "notifications with invalid package",
+			send: func(c *Commands) error {
+				_, err := c.SetAppNotifications(DeviceTarget("d"), "app", NotificationsEnabled)
+				return err
+			},
+			wantErr: `invalid package name "app"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, sent := captureServer(t)
+			err := tt.send(&Commands{Request: testRequest(server.URL)})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				if len(*sent) != 0 {
+					t.Errorf("sent %d commands after a validation error", len(*sent))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(*sent) != 1 {
+				t.Fatalf("sent %d commands, want 1", len(*sent))
+			}
+			got := (*sent)[0]
+			if got.Command != tt.wantCmd || !reflect.DeepEqual(got.Args, tt.wantArgs) {
+				t.Errorf("sent %s %v, want %s %v", got.Command, got.Args, tt.wantCmd, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// CancelResult reports which devices a cancellation took effect on
+type CancelResult struct {
+	RequestID       string
+	Cancelled       []string // Devices on which the command is now cancelled
+	AlreadyExecuted []string // Devices that had left the queue and could not be cancelled
+	Pending         []string // Devices still queued or scheduled that the cancellation did not reach
+	NotTargeted     []string // Requested devices the command request does not target
+}
+
+// Cancel cancels a command request on every device where it is still queued
+func (c *Commands) Cancel(requestID string) (*CancelResult, error) {
+	return c.CancelContext(context.Background(), requestID)
+}
+
+// CancelContext is like Cancel but carries ctx for cancellation and deadlines
+func (c *Commands) CancelContext(ctx context.Context, requestID string) (*CancelResult, error) {
+	return c.CancelDevicesContext(ctx, requestID)
+}
+
+// CancelDevices cancels a command request on the given devices where it is
+// still queued or scheduled. With no devices every targeted device is considered;
+// devices the request does not target are reported in NotTargeted.
+func (c *Commands) CancelDevices(requestID string, devices ...string) (*CancelResult, error) {
+	return c.CancelDevicesContext(context.Background(), requestID, devices...)
+}
+
+// CancelDevicesContext is like CancelDevices but carries ctx for cancellation and deadlines
+func (c *Commands) CancelDevicesContext(ctx context.Context, requestID string, devices ...string) (*CancelResult, error) {
+	statuses, err := c.StatusesContext(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, status := range selectDevices(statuses, devices) {
+		if isCancellable(status.State) {
+			pending = append(pending, status.Device)
+		}
+	}
+
+	if len(pending) > 0 {
+		body := map[string]interface{}{"devices": pending}
+		if err := c.Request.Do(ctx, http.MethodPost, c.commandEndpoint(requestID)+"cancel/", nil, body, nil); err != nil {
+			return nil, err
+		}
+
+		// Devices may pick the command up between the status check and the
+		// cancellation, so the outcome is read back rather than assumed
+		if statuses, err = c.StatusesContext(ctx, requestID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CancelResult{RequestID: requestID, NotTargeted: notTargeted(statuses, devices)}
+	for _, status := range selectDevices(statuses, devices) {
+		switch {
+		case status.State == CommandStateCancelled:
+			result.Cancelled = append(result.Cancelled, status.Device)
+		case isCancellable(status.State):
+			result.Pending = append(result.Pending, status.Device)
+		default:
+			result.AlreadyExecuted = append(result.AlreadyExecuted, status.Device)
+		}
+	}
+	return result, nil
+}
+
+// isCancellable reports whether a command in state has not reached the device yet
+func isCancellable(state CommandState) bool {
+	return state == CommandStateQueued || state == CommandStateScheduled
+}
+
+// notTargeted returns the devices that have no status in statuses
+func notTargeted(statuses []CommandStatus, devices []string) []string {
+	var missing []string
+	for _, device := range devices {
+		targeted := slices.ContainsFunc(statuses, func(status CommandStatus) bool {
+			return status.Device == device
+		})
+		if !targeted && !slices.Contains(missing, device) {
+			missing = append(missing, device)
+		}
+	}
+	return missing
+}
+
+// selectDevices returns the statuses of devices, or all statuses if devices is empty
+func selectDevices(statuses []CommandStatus, devices []string) []CommandStatus {
+	if len(devices) == 0 {
+		return statuses
+	}
+	var selected []CommandStatus
+	for _, status := range statuses {
+		if slices.Contains(devices, status.Device) {
+			selected = append(selected, status)
+		}
+	}
+	return selected
+}
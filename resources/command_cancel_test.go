@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCancelDevices(t *testing.T) {
+	var requested, cancelled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel/") {
+			var body struct{ Devices []string }
+			json.NewDecoder(r.Body).Decode(&body)
+			requested = append(requested, body.Devices...)
+			// The API acknowledges every device but never cancels "stuck"
+			for _, device := range body.Devices {
+				if device != "stuck" {
+					cancelled = append(cancelled, device)
+				}
+			}
+			return
+		}
+		statuses := []CommandStatus{
+			{Device: "queued", State: CommandStateQueued},
+			{Device: "done", State: CommandStateSuccess},
+			{Device: "other", State: CommandStateQueued},
+			{Device: "stuck", State: CommandStateScheduled},
+		}
+		for i, status := range statuses {
+			if slices.Contains(cancelled, status.Device) {
+				statuses[i].State = CommandStateCancelled
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": statuses})
+	}))
+	defer server.Close()
+	commands := &Commands{Request: testRequest(server.URL)}
+
+	result, err := commands.CancelDevices("r1", "queued", "done", "stuck", "typo", "typo")
+	if err != nil {
+		t.Fatalf("CancelDevices() error = %v", err)
+	}
+	if !slices.Equal(requested, []string{"queued", "stuck"}) {
+		t.Errorf("asked the API to cancel %v, want [queued stuck]", requested)
+	}
+	if !slices.Equal(result.Cancelled, []string{"queued"}) ||
+		!slices.Equal(result.AlreadyExecuted, []string{"done"}) ||
+		!slices.Equal(result.Pending, []string{"stuck"}) ||
+		!slices.Equal(result.NotTargeted, []string{"typo"}) {
+		t.Errorf("CancelDevices() = %+v", result)
+	}
+}
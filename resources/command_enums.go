@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GPSMode is the location mode applied by SetGPSState
+type GPSMode int
+
+const (
+	GPSHighAccuracy  GPSMode = iota // GPS, Wi-Fi and mobile networks
+	GPSSensorsOnly                  // GPS only
+	GPSBatterySaving                // Wi-Fi and mobile networks only
+	GPSOff
+	GPSOn
+)
+
+var gpsModeNames = []string{"HIGH_ACCURACY", "SENSORS_ONLY", "BATTERY_SAVING", "OFF", "ON"}
+
+func (m GPSMode) String() string {
+	return enumName(gpsModeNames, int(m), "GPSMode")
+}
+
+// ParseGPSMode parses a mode name such as "high-accuracy" or its number
+func ParseGPSMode(s string) (GPSMode, error) {
+	i, err := parseEnum(gpsModeNames, s, "GPS mode")
+	return GPSMode(i), err
+}
+
+// Set implements flag.Value
+func (m *GPSMode) Set(s string) error {
+	mode, err := ParseGPSMode(s)
+	if err == nil {
+		*m = mode
+	}
+	return err
+}
+
+// RotationMode is the screen orientation applied by SetRotationState
+type RotationMode int
+
+const (
+	RotationAuto      RotationMode = iota // Follow the device sensor
+	RotationPortrait                      // Portrait only
+	RotationLandscape                     // Landscape only
+)
+
+var rotationModeNames = []string{"AUTO", "PORTRAIT", "LANDSCAPE"}
+
+func (m RotationMode) String() string {
+	return enumName(rotationModeNames, int(m), "RotationMode")
+}
+
+// ParseRotationMode parses a mode name such as "portrait" or its number
+func ParseRotationMode(s string) (RotationMode, error) {
+	i, err := parseEnum(rotationModeNames, s, "rotation mode")
+	return RotationMode(i), err
+}
+
+// Set implements flag.Value
+func (m *RotationMode) Set(s string) error {
+	mode, err := ParseRotationMode(s)
+	if err == nil {
+		*m = mode
+	}
+	return err
+}
+
+// AudioStream is the audio stream whose volume SetVolume changes
+type AudioStream int
+
+const (
+	StreamRing AudioStream = iota
+	StreamNotification
+	StreamAlarm
+	StreamMusic
+)
+
+var audioStreamNames = []string{"RING", "NOTIFICATION", "ALARM", "MUSIC"}
+
+func (s AudioStream) String() string {
+	return enumName(audioStreamNames, int(s), "AudioStream")
+}
+
+// ParseAudioStream parses a stream name such as "music" or its number
+func ParseAudioStream(s string) (AudioStream, error) {
+	i, err := parseEnum(audioStreamNames, s, "audio stream")
+	return AudioStream(i), err
+}
+
+// Set implements flag.Value
+func (s *AudioStream) Set(value string) error {
+	stream, err := ParseAudioStream(value)
+	if err == nil {
+		*s = stream
+	}
+	return err
+}
+
+// AppState is the visibility state applied by SetAppState
+type AppState string
+
+const (
+	AppStateShow    AppState = "SHOW"    // App is visible and usable
+	AppStateHide    AppState = "HIDE"    // App is hidden from the launcher
+	AppStateDisable AppState = "DISABLE" // App is disabled and cannot run
+)
+
+var appStateNames = []string{string(AppStateShow), string(AppStateHide), string(AppStateDisable)}
+
+func (s AppState) String() string {
+	return string(s)
+}
+
+// ParseAppState parses a state name such as "hide"
+func ParseAppState(s string) (AppState, error) {
+	i, err := parseEnum(appStateNames, s, "app state")
+	if err != nil {
+		return "", err
+	}
+	return AppState(appStateNames[i]), nil
+}
+
+// Set implements flag.Value
+func (s *AppState) Set(value string) error {
+	state, err := ParseAppState(value)
+	if err == nil {
+		*s = state
+	}
+	return err
+}
+
+// enumName returns the name of value, or typeName(value) if it is out of range
+func enumName(names []string, value int, typeName string) string {
+	if value >= 0 && value < len(names) {
+		return names[value]
+	}
+	return fmt.Sprintf("%s(%d)", typeName, value)
+}
+
+// parseEnum matches s against names case-insensitively, treating "-" and
+// spaces like "_", and also accepts the numeric index of a name
+func parseEnum(names []string, s, kind string) (int, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(s))
+	normalized = strings.NewReplacer("-", "_", " ", "_").Replace(normalized)
+	for i, name := range names {
+		if normalized == name {
+			return i, nil
+		}
+	}
+	if i, err := strconv.Atoi(normalized); err == nil && i >= 0 && i < len(names) {
+		return i, nil
+	}
+	return 0, fmt.Errorf("unknown %s %q, expected one of %s", kind, s, strings.Join(names, ", "))
+}
@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseEnum(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"HIGH_ACCURACY", 0, false},
+		{"high_accuracy", 0, false},
+		{"High-Accuracy", 0, false},
+		{"sensors only", 1, false},
+		{"  battery-saving  ", 2, false},
+		{"4", 4, false},
+		{"0", 0, false},
+		{"5", 0, true},
+		{"-1", 0, true},
+		{"", 0, true},
+		{"HIGH", 0, true},
+		{"high__accuracy", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseEnum(gpsModeNames, tt.input, "GPS mode")
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "expected one of HIGH_ACCURACY, SENSORS_ONLY") {
+					t.Errorf("parseEnum(%q) = %d, %v, want an error listing the names", tt.input, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("parseEnum(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	tests := []struct {
+		value fmt.Stringer
+		want  string
+	}{
+		{GPSHighAccuracy, "HIGH_ACCURACY"},
+		{GPSOn, "ON"},
+		{GPSMode(9), "GPSMode(9)"},
+		{GPSMode(-1), "GPSMode(-1)"},
+		{RotationLandscape, "LANDSCAPE"},
+		{RotationMode(3), "RotationMode(3)"},
+		{StreamMusic, "MUSIC"},
+		{AudioStream(7), "AudioStream(7)"},
+		{AppStateHide, "HIDE"},
+	}
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestParseAppState(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    AppState
+		wantErr bool
+	}{
+		{"show", AppStateShow, false},
+		{"Hide", AppStateHide, false},
+		{" DISABLE ", AppStateDisable, false},
+		{"1", AppStateHide, false},
+		{"enable", "", true},
+		{"3", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAppState(tt.input)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseAppState(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnumSet(t *testing.T) {
+	var (
+		gps      = GPSOff
+		rotation = RotationPortrait
+		stream   = StreamRing
+		state    = AppStateShow
+	)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&gps, "gps", "")
+	flags.Var(&rotation, "rotation", "")
+	flags.Var(&stream, "stream", "")
+	flags.Var(&state, "state", "")
+
+	if err := flags.Parse([]string{"-gps", "battery-saving", "-rotation", "2", "-stream", "Alarm", "-state", "disable"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if gps != GPSBatterySaving || rotation != RotationLandscape || stream != StreamAlarm || state != AppStateDisable {
+		t.Errorf("parsed %v %v %v %v", gps, rotation, stream, state)
+	}
+
+	// A rejected value leaves the previous one in place
+	for _, value := range []flag.Value{&gps, &rotation, &stream, &state} {
+		before := value.String()
+		if err := value.Set("bogus"); err == nil {
+			t.Errorf("Set(bogus) on %s succeeded", before)
+		}
+		if after := value.String(); after != before {
+			t.Errorf("failed Set changed %s to %s", before, after)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommandList is a single page of command requests
+type CommandList = Page[CommandResponse]
+
+// CommandFilter narrows the command history returned by List.
+// The zero value matches every command request.
+type CommandFilter struct {
+	Command  Command      // Only requests for this command
+	Device   string       // Only requests targeting this device
+	Group    string       // Only requests targeting this group
+	IssuedBy int          // Only requests issued by this user ID
+	State    CommandState // Only requests in this state
+	Ordering string       // Field to order by, prefix with "-" for descending
+
+	CreatedAfter  time.Time // Only requests created at or after this time
+	CreatedBefore time.Time // Only requests created at or before this time
+
+	Limit  int // Page size, 0 uses the API default
+	Offset int // Number of requests to skip
+}
+
+// commandOrderingFields lists the fields the commands API can order by
+var commandOrderingFields = map[string]bool{
+	"created_on": true,
+	"updated_on": true,
+	"command":    true,
+}
+
+// Validate checks the filter for invalid values and combinations
+func (f *CommandFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	var errs []error
+	if f.IssuedBy < 0 {
+		errs = append(errs, errors.New("issued by must be a valid user ID"))
+	}
+	if f.Ordering != "" && !commandOrderingFields[strings.TrimPrefix(f.Ordering, "-")] {
+		errs = append(errs, fmt.Errorf("cannot order commands by %q", f.Ordering))
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && f.CreatedAfter.After(f.CreatedBefore) {
+		errs = append(errs, errors.New("created after must not be later than created before"))
+	}
+	errs = append(errs, validatePageParams(f.Limit, f.Offset)...)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid command filter: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Values validates the filter and encodes it as query parameters
+func (f *CommandFilter) Values() (url.Values, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	queryParams := url.Values{}
+	if f == nil {
+		return queryParams, nil
+	}
+
+	setIfNotEmpty(queryParams, "command", string(f.Command))
+	setIfNotEmpty(queryParams, "devices", f.Device)
+	setIfNotEmpty(queryParams, "groups", f.Group)
+	setIfNotEmpty(queryParams, "state", string(f.State))
+	setIfNotEmpty(queryParams, "ordering", f.Ordering)
+
+	if f.IssuedBy > 0 {
+		queryParams.Set("issued_by", strconv.Itoa(f.IssuedBy))
+	}
+	if !f.CreatedAfter.IsZero() {
+		queryParams.Set("created_on__gte", f.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if !f.CreatedBefore.IsZero() {
+		queryParams.Set("created_on__lte", f.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+	setPageParams(queryParams, f.Limit, f.Offset)
+	return queryParams, nil
+}
+
+// List lists a single page of past command requests matching filter
+func (c *Commands) List(filter *CommandFilter) (*CommandList, error) {
+	return c.ListContext(context.Background(), filter)
+}
+
+// ListContext is like List but carries ctx for cancellation and deadlines
+func (c *Commands) ListContext(ctx context.Context, filter *CommandFilter) (*CommandList, error) {
+	query, err := filter.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	var list CommandList
+	if err := c.Request.Do(ctx, http.MethodGet, c.commandEndpoint(""), query, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// All iterates over every command request matching filter, following the
+// API's next links page by page. The filter's Limit sets the page size.
+func (c *Commands) All(ctx context.Context, filter *CommandFilter) iter.Seq2[CommandResponse, error] {
+	query, err := filter.Values()
+	if err != nil {
+		return failed[CommandResponse](err)
+	}
+	return paginate[CommandResponse](ctx, c.Request, c.commandEndpoint(""), query)
+}
+
+// ListAll collects every command request matching filter into a slice
+func (c *Commands) ListAll(ctx context.Context, filter *CommandFilter) ([]CommandResponse, error) {
+	return collect(c.All(ctx, filter))
+}
@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCommandFilterValues(t *testing.T) {
+	after := time.Date(2024, 5, 7, 0, 0, 0, 0, time.FixedZone("IST", 5*3600+1800))
+	before := after.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  *CommandFilter
+		want    url.Values
+		wantErr bool
+	}{
+		{"nil", nil, url.Values{}, false},
+		{"zero", &CommandFilter{}, url.Values{}, false},
+		{
+			name: "all fields",
+			filter: &CommandFilter{
+				Command:       CommandReboot,
+				Device:        "d1",
+				Group:         "g1",
+				IssuedBy:      42,
+				State:         CommandStateSuccess,
+				Ordering:      "-created_on",
+				CreatedAfter:  after,
+				CreatedBefore: before,
+				Limit:         50,
+				Offset:        100,
+			},
+			want: url.Values{
+				"command":         {"REBOOT"},
+				"devices":         {"d1"},
+				"groups":          {"g1"},
+				"issued_by":       {"42"},
+				"state":           {"Command Success"},
+				"ordering":        {"-created_on"},
+				"created_on__gte": {"2024-05-06T18:30:00Z"},
+				"created_on__lte": {"2024-05-07T18:30:00Z"},
+				"limit":           {"50"},
+				"offset":          {"100"},
+			},
+		},
+		{"unknown ordering", &CommandFilter{Ordering: "device"}, nil, true},
+		{"inverted range", &CommandFilter{CreatedAfter: before, CreatedBefore: after}, nil, true},
+		{"limit too large", &CommandFilter{Limit: maxPageSize + 1}, nil, true},
+		{"negative offset", &CommandFilter{Offset: -1}, nil, true},
+		{"negative issuer", &CommandFilter{IssuedBy: -1}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Encode() != tt.want.Encode() {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
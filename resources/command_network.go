@@ -0,0 +1,227 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+)
+
+// IPConfig is a static IP configuration for a network interface.
+// Addresses are given in their textual form and parsed with net/netip.
+type IPConfig struct {
+	Address      string       // e.g. "192.168.10.20"
+	PrefixLength int          // e.g. 24
+	Gateway      string       // e.g. "192.168.10.1"
+	DNSServers   []string     // At most two servers are used by Android
+	Proxy        *ProxyConfig // Optional HTTP proxy
+}
+
+// ProxyConfig is an HTTP proxy, either a manual host and port or a PAC file URL
+type ProxyConfig struct {
+	Host       string
+	Port       int
+	Exclusions []string // Hosts that bypass the proxy
+	PACURL     string
+}
+
+// Validate parses every address and checks the configuration is consistent
+func (cfg IPConfig) Validate() error {
+	_, err := cfg.args()
+	return err
+}
+
+// args validates the configuration and converts it to command arguments
+func (cfg IPConfig) args() (map[string]interface{}, error) {
+	var errs []error
+
+	addr, err := netip.ParseAddr(cfg.Address)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid address: %w", err))
+	} else if addr.IsUnspecified() || addr.IsLoopback() || addr.IsMulticast() {
+		errs = append(errs, fmt.Errorf("address %s cannot be assigned to an interface", addr))
+	}
+
+	var prefix netip.Prefix
+	if addr.IsValid() {
+		if cfg.PrefixLength <= 0 || cfg.PrefixLength > addr.BitLen() {
+			errs = append(errs, fmt.Errorf("prefix length must be between 1 and %d", addr.BitLen()))
+		} else {
+			prefix = netip.PrefixFrom(addr, cfg.PrefixLength).Masked()
+			if reservedIPv4(prefix, addr) {
+				errs = append(errs, fmt.Errorf("address %s is the network or broadcast address of %s", addr, prefix))
+			}
+		}
+	}
+
+	gateway, err := netip.ParseAddr(cfg.Gateway)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid gateway: %w", err))
+	} else if addr.IsValid() {
+		switch {
+		case gateway.Is4() != addr.Is4():
+			errs = append(errs, errors.New("gateway and address must be the same IP family"))
+		case gateway == addr:
+			errs = append(errs, errors.New("gateway must differ from the address"))
+		case gateway.Is6() && gateway.IsLinkLocalUnicast():
+			// IPv6 routers are usually reached through their link-local address
+		case prefix.IsValid() && !prefix.Contains(gateway):
+			errs = append(errs, fmt.Errorf("gateway %s is outside %s", gateway, prefix))
+		case reservedIPv4(prefix, gateway):
+			errs = append(errs, fmt.Errorf("gateway %s is the network or broadcast address of %s", gateway, prefix))
+		}
+	}
+
+	dnsServers := make([]string, 0, len(cfg.DNSServers))
+	for _, server := range cfg.DNSServers {
+		dns, err := netip.ParseAddr(server)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid DNS server: %w", err))
+			continue
+		}
+		dnsServers = append(dnsServers, dns.String())
+	}
+	if len(cfg.DNSServers) > 2 {
+		errs = append(errs, errors.New("at most two DNS servers are supported"))
+	}
+
+	if cfg.Proxy != nil {
+		if err := cfg.Proxy.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid IP config: %w", errors.Join(errs...))
+	}
+
+	args := map[string]interface{}{
+		"ip_address":    addr.String(),
+		"prefix_length": cfg.PrefixLength,
+		"gateway":       gateway.String(),
+		"dns_servers":   dnsServers,
+	}
+	if cfg.Proxy != nil {
+		args["proxy"] = cfg.Proxy.args()
+	}
+	return args, nil
+}
+
+// reservedIPv4 reports whether addr is the network or broadcast address of an
+// IPv4 prefix. Point-to-point /31 and single host /32 prefixes have neither.
+func reservedIPv4(prefix netip.Prefix, addr netip.Addr) bool {
+	if !prefix.IsValid() || !addr.Is4() || prefix.Bits() > 30 {
+		return false
+	}
+	broadcast := prefix.Addr().As4()
+	for i := prefix.Bits(); i < 32; i++ {
+		broadcast[i/8] |= 1 << (7 - i%8)
+	}
+	return addr == prefix.Addr() || addr == netip.AddrFrom4(broadcast)
+}
+
+func (p *ProxyConfig) validate() error {
+	if p.PACURL != "" {
+		if p.Host != "" || p.Port != 0 {
+			return errors.New("proxy cannot have both a PAC URL and a host")
+		}
+		u, err := url.Parse(p.PACURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid proxy PAC URL %q", p.PACURL)
+		}
+		return nil
+	}
+	if p.Host == "" {
+		return errors.New("proxy host is required")
+	}
+	if p.Port < 1 || p.Port > 65535 {
+		return errors.New("proxy port must be between 1 and 65535")
+	}
+	return nil
+}
+
+func (p *ProxyConfig) args() map[string]interface{} {
+	if p.PACURL != "" {
+		return map[string]interface{}{"pac_url": p.PACURL}
+	}
+	args := map[string]interface{}{
+		"host": p.Host,
+		"port": p.Port,
+	}
+	if len(p.Exclusions) > 0 {
+		args["exclusion_list"] = p.Exclusions
+	}
+	return args
+}
+
+// EthernetSettings configures the wired interface of a device.
+// Set DHCP to obtain an address automatically, or StaticIP for a fixed one.
+type EthernetSettings struct {
+	DHCP     bool
+	StaticIP *IPConfig
+	Proxy    *ProxyConfig // Proxy used with DHCP; static configs carry their own
+}
+
+// Validate checks that exactly one addressing mode is configured
+func (s EthernetSettings) Validate() error {
+	_, err := s.args()
+	return err
+}
+
+func (s EthernetSettings) args() (map[string]interface{}, error) {
+	switch {
+	case s.DHCP && s.StaticIP != nil:
+		return nil, errors.New("ethernet settings cannot use both DHCP and a static IP")
+	case !s.DHCP && s.StaticIP == nil:
+		return nil, errors.New("ethernet settings require DHCP or a static IP")
+	case s.StaticIP != nil && s.Proxy != nil:
+		return nil, errors.New("set the proxy on the static IP config instead")
+	}
+
+	if s.StaticIP != nil {
+		args, err := s.StaticIP.args()
+		if err != nil {
+			return nil, err
+		}
+		args["ip_assignment"] = "STATIC"
+		return args, nil
+	}
+
+	args := map[string]interface{}{"ip_assignment": "DHCP"}
+	if s.Proxy != nil {
+		if err := s.Proxy.validate(); err != nil {
+			return nil, fmt.Errorf("invalid ethernet settings: %w", err)
+		}
+		args["proxy"] = s.Proxy.args()
+	}
+	return args, nil
+}
+
+// SetStaticIP assigns a static IP configuration to the target devices
+func (c *Commands) SetStaticIP(target Target, config IPConfig) (*CommandResponse, error) {
+	return c.SetStaticIPContext(context.Background(), target, config)
+}
+
+// SetStaticIPContext is like SetStaticIP but carries ctx for cancellation and deadlines
+func (c *Commands) SetStaticIPContext(ctx context.Context, target Target, config IPConfig) (*CommandResponse, error) {
+	args, err := config.args()
+	if err != nil {
+		return nil, err
+	}
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetStaticIP, args))
+}
+
+// SetEthernetSettings configures the Ethernet interface of the target devices
+func (c *Commands) SetEthernetSettings(target Target, settings EthernetSettings) (*CommandResponse, error) {
+	return c.SetEthernetSettingsContext(context.Background(), target, settings)
+}
+
+// SetEthernetSettingsContext is like SetEthernetSettings but carries ctx for cancellation and deadlines
+func (c *Commands) SetEthernetSettingsContext(ctx context.Context, target Target, settings EthernetSettings) (*CommandResponse, error) {
+	args, err := settings.args()
+	if err != nil {
+		return nil, err
+	}
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetEthernetSettings, args))
+}
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIPConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  IPConfig
+		wantErr string
+	}{
+		{"IPv4", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.10.1", DNSServers: []string{"1.1.1.1", "8.8.8.8"}}, ""},
+		{"IPv4 point to point", IPConfig{Address: "10.0.0.0", PrefixLength: 31, Gateway: "10.0.0.1"}, ""},
+		{"IPv6 in prefix gateway", IPConfig{Address: "2001:db8::10", PrefixLength: 64, Gateway: "2001:db8::1"}, ""},
+		{"IPv6 link-local gateway", IPConfig{Address: "2001:db8::10", PrefixLength: 64, Gateway: "fe80::1"}, ""},
+		{"malformed address", IPConfig{Address: "192.168.10", PrefixLength: 24, Gateway: "192.168.10.1"}, "invalid address"},
+		{"loopback address", IPConfig{Address: "127.0.0.2", PrefixLength: 8, Gateway: "127.0.0.1"}, "cannot be assigned"},
+		{"network address", IPConfig{Address: "192.168.10.0", PrefixLength: 24, Gateway: "192.168.10.1"}, "network or broadcast address"},
+		{"broadcast address", IPConfig{Address: "192.168.10.255", PrefixLength: 24, Gateway: "192.168.10.1"}, "network or broadcast address"},
+		{"broadcast gateway", IPConfig{Address: "10.1.2.3", PrefixLength: 20, Gateway: "10.1.15.255"}, "gateway 10.1.15.255 is the network or broadcast"},
+		{"prefix too long", IPConfig{Address: "192.168.10.20", PrefixLength: 33, Gateway: "192.168.10.1"}, "prefix length must be between 1 and 32"},
+		{"missing prefix", IPConfig{Address: "2001:db8::10", Gateway: "2001:db8::1"}, "prefix length must be between 1 and 128"},
+		{"gateway outside prefix", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.11.1"}, "outside 192.168.10.0/24"},
+		{"IPv4 link-local gateway", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "169.254.0.1"}, "outside"},
+		{"mixed families", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "fe80::1"}, "same IP family"},
+		{"gateway is the address", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.10.20"}, "must differ"},
+		{"bad DNS server", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.10.1", DNSServers: []string{"dns.google"}}, "invalid DNS server"},
+		{"too many DNS servers", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.10.1", DNSServers: []string{"1.1.1.1", "1.0.0.1", "8.8.8.8"}}, "at most two"},
+		{"bad proxy", IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.10.1", Proxy: &ProxyConfig{Host: "proxy"}}, "proxy port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProxyConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   ProxyConfig
+		wantErr string
+	}{
+		{"manual", ProxyConfig{Host: "proxy.local", Port: 3128}, ""},
+		{"PAC", ProxyConfig{PACURL: "https://example.com/proxy.pac"}, ""},
+		{"PAC and host", ProxyConfig{PACURL: "https://example.com/proxy.pac", Host: "proxy.local"}, "both a PAC URL and a host"},
+		{"PAC without scheme", ProxyConfig{PACURL: "example.com/proxy.pac"}, "invalid proxy PAC URL"},
+		{"PAC over ftp", ProxyConfig{PACURL: "ftp://example.com/proxy.pac"}, "invalid proxy PAC URL"},
+		{"missing host", ProxyConfig{Port: 3128}, "host is required"},
+		{"port out of range", ProxyConfig{Host: "proxy.local", Port: 70000}, "port must be between"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.proxy.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEthernetSettings(t *testing.T) {
+	static := &IPConfig{Address: "192.168.10.20", PrefixLength: 24, Gateway: "192.168.10.1"}
+	proxy := &ProxyConfig{Host: "proxy.local", Port: 3128, Exclusions: []string{"*.local"}}
+
+	tests := []struct {
+		name     string
+		settings EthernetSettings
+		want     map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name:     "DHCP",
+			settings: EthernetSettings{DHCP: true},
+			want:     map[string]interface{}{"ip_assignment": "DHCP"},
+		},
+		{
+			name:     "DHCP with proxy",
+			settings: EthernetSettings{DHCP: true, Proxy: proxy},
+			want: map[string]interface{}{
+				"ip_assignment": "DHCP",
+				"proxy":         map[string]interface{}{"host": "proxy.local", "port": 3128, "exclusion_list": []string{"*.local"}},
+			},
+		},
+		{
+			name:     "static",
+			settings: EthernetSettings{StaticIP: static},
+			want: map[string]interface{}{
+				"ip_assignment": "STATIC",
+				"ip_address":    "192.168.10.20",
+				"prefix_length": 24,
+				"gateway":       "192.168.10.1",
+				"dns_servers":   []string{},
+			},
+		},
+		{name: "neither", settings: EthernetSettings{}, wantErr: "require DHCP or a static IP"},
+		{name: "both", settings: EthernetSettings{DHCP: true, StaticIP: static}, wantErr: "both DHCP and a static IP"},
+		{name: "proxy beside static", settings: EthernetSettings{StaticIP: static, Proxy: proxy}, wantErr: "on the static IP config"},
+		{name: "invalid DHCP proxy", settings: EthernetSettings{DHCP: true, Proxy: &ProxyConfig{}}, wantErr: "host is required"},
+		{name: "invalid static config", settings: EthernetSettings{StaticIP: &IPConfig{Address: "x"}}, wantErr: "invalid IP config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.settings.args()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("args() error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				if tt.settings.Validate() == nil {
+					t.Error("Validate() succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("args() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("args() = %#v, want %#v", got, tt.want)
+			}
+			if err := ValidateCommandArgs(CommandSetEthernetSettings, got); err != nil {
+				t.Errorf("args() do not pass the registry: %v", err)
+			}
+		})
+	}
+}
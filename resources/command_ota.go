@@ -0,0 +1,154 @@
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// OTA manager component that performs seamless A/B installs
+const (
+	otaManagerComponent = "io.esper.otamanager/io.esper.otamanager.OTAUpdateService"
+	otaInstallAction    = "io.esper.otamanager.INSTALL_OTA"
+)
+
+// OTAInstallType selects how the A/B payload is delivered to the device
+type OTAInstallType string
+
+const (
+	OTAStreaming    OTAInstallType = "STREAMING"     // Payload is streamed straight into the inactive slot
+	OTANonStreaming OTAInstallType = "NON_STREAMING" // Package is downloaded in full before installing
+)
+
+// OTAPropertyFile locates an entry inside the OTA package, as listed in its
+// metadata. Streaming installs use these to fetch only the parts they need.
+type OTAPropertyFile struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size,string"`
+	Offset   int64  `json:"offset,string"`
+}
+
+// OTAUpdate describes a seamless A/B OS update package
+type OTAUpdate struct {
+	Name              string            // Build name shown on the device
+	URL               string            // HTTPS location of the full OTA package
+	FileHash          string            // Base64 SHA-256 of payload.bin
+	FileSize          int64             // Size of payload.bin in bytes
+	MetadataHash      string            // Base64 SHA-256 of the payload metadata
+	MetadataSize      int64             // Size of the payload metadata in bytes
+	PropertyFiles     []OTAPropertyFile // Required for streaming installs
+	SwitchSlotAllowed bool              // Reboot into the new slot once installed
+	InstallType       OTAInstallType
+}
+
+// otaMetadata is the JSON document passed to the OTA manager in the metaData extra
+type otaMetadata struct {
+	SwitchSlotAllowed   bool                  `json:"switch_slot_allowed"`
+	ABStreamingMetadata *otaStreamingMetadata `json:"ab_streaming_metadata,omitempty"`
+	ABInstallType       OTAInstallType        `json:"ab_install_type"`
+	Name                string                `json:"name"`
+	HeaderKeyValuePairs []string              `json:"header_key_value_pairs"`
+	URL                 string                `json:"url"`
+}
+
+type otaStreamingMetadata struct {
+	PropertyFiles []OTAPropertyFile `json:"property_files"`
+}
+
+// Validate checks the update before it is sent to devices
+func (u OTAUpdate) Validate() error {
+	var errs []error
+	if u.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if parsed, err := url.Parse(u.URL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		errs = append(errs, fmt.Errorf("URL must be an absolute https URL, got %q", u.URL))
+	}
+	if !validSHA256(u.FileHash) {
+		errs = append(errs, errors.New("file hash must be a base64 encoded SHA-256 digest"))
+	}
+	if !validSHA256(u.MetadataHash) {
+		errs = append(errs, errors.New("metadata hash must be a base64 encoded SHA-256 digest"))
+	}
+	if u.FileSize <= 0 || u.MetadataSize <= 0 {
+		errs = append(errs, errors.New("file and metadata sizes must be positive"))
+	}
+	for _, file := range u.PropertyFiles {
+		if file.Filename == "" || file.Size < 0 || file.Offset < 0 {
+			errs = append(errs, fmt.Errorf("invalid property file %+v", file))
+		}
+	}
+
+	switch u.InstallType {
+	case OTAStreaming:
+		for _, required := range []string{"payload.bin", "payload_metadata.bin", "payload_properties.txt"} {
+			if !slices.ContainsFunc(u.PropertyFiles, func(f OTAPropertyFile) bool { return f.Filename == required }) {
+				errs = append(errs, fmt.Errorf("streaming installs require the %s property file", required))
+			}
+		}
+	case OTANonStreaming:
+	default:
+		errs = append(errs, fmt.Errorf("unknown install type %q", u.InstallType))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid OTA update: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func validSHA256(hash string) bool {
+	digest, err := base64.StdEncoding.DecodeString(hash)
+	return err == nil && len(digest) == 32
+}
+
+// metadata encodes the JSON string expected by the OTA manager
+func (u OTAUpdate) metadata() (string, error) {
+	meta := otaMetadata{
+		SwitchSlotAllowed: u.SwitchSlotAllowed,
+		ABInstallType:     u.InstallType,
+		Name:              u.Name,
+		HeaderKeyValuePairs: []string{
+			"FILE_HASH=" + u.FileHash,
+			fmt.Sprintf("FILE_SIZE=%d", u.FileSize),
+			"METADATA_HASH=" + u.MetadataHash,
+			fmt.Sprintf("METADATA_SIZE=%d", u.MetadataSize),
+		},
+		URL: u.URL,
+	}
+	if len(u.PropertyFiles) > 0 {
+		meta.ABStreamingMetadata = &otaStreamingMetadata{PropertyFiles: u.PropertyFiles}
+	}
+
+	encoded, err := json.Marshal(meta)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode OTA metadata: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// InstallOTA installs a seamless A/B OS update on the target devices through the Esper OTA manager
+func (c *Commands) InstallOTA(target Target, update OTAUpdate) (*CommandResponse, error) {
+	return c.InstallOTAContext(context.Background(), target, update)
+}
+
+// InstallOTAContext is like InstallOTA but carries ctx for cancellation and deadlines
+func (c *Commands) InstallOTAContext(ctx context.Context, target Target, update OTAUpdate) (*CommandResponse, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	metadata, err := update.metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	script := LaunchServiceScript(otaManagerComponent, otaInstallAction, ServiceTypeBackground, map[string]interface{}{
+		"otaType":  "SEAMLESS",
+		"metaData": metadata,
+	})
+	return c.UpdateCustomSettingsContext(ctx, target, DeviceTypeAll, NewCustomSettings().AddScript(script))
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+)
+
+// legacyOTAMetadata is the metaData string the hand-written example in
+// cmd/test sent before OTAUpdate existed. The OTA manager parses it as is.
+const legacyOTAMetadata = `{"switch_slot_allowed":true,"ab_streaming_metadata":{"property_files":[{"filename":"payload_metadata.bin","size":"67033","offset":"2706"},{"filename":"payload.bin","size":"817266612","offset":"2706"},{"filename":"payload_properties.txt","size":"154","offset":"817269376"},{"filename":"apex_info.pb","size":"925","offset":"1569"},{"filename":"care_map.pb","size":"118","offset":"2541"},{"filename":"metadata","size":"653","offset":"69"},{"filename":"metadata.pb","size":"731","offset":"790"}]},"ab_install_type":"STREAMING","name":"13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne","header_key_value_pairs":["FILE_HASH=wPYevFfTewvnucpM3aRHF1RSxA2ELM6HY0Fp97AKBFA=","FILE_SIZE=817266612","METADATA_HASH=qbr5WBzNRvv8mybFXVQ1N6BNhpIX++0wnAN8VUhUAjI=","METADATA_SIZE=66766"],"url":"https://ota.esper.cloud/jenkins_builds/OSBuilds/sparrow/thirteen/arm64/1027/artifacts/foundation-13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne-fullota.zip"}`
+
+// legacyOTAUpdate is the typed equivalent of legacyOTAMetadata
+func legacyOTAUpdate() OTAUpdate {
+	return OTAUpdate{
+		Name:         "13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne",
+		URL:          "https://ota.esper.cloud/jenkins_builds/OSBuilds/sparrow/thirteen/arm64/1027/artifacts/foundation-13.6.2.1027-arm64-gsi-20240530-turnkey-TYD-STAGING-nPlusOne-fullota.zip",
+		FileHash:     "wPYevFfTewvnucpM3aRHF1RSxA2ELM6HY0Fp97AKBFA=",
+		FileSize:     817266612,
+		MetadataHash: "qbr5WBzNRvv8mybFXVQ1N6BNhpIX++0wnAN8VUhUAjI=",
+		MetadataSize: 66766,
+		PropertyFiles: []OTAPropertyFile{
+			{Filename: "payload_metadata.bin", Size: 67033, Offset: 2706},
+			{Filename: "payload.bin", Size: 817266612, Offset: 2706},
+			{Filename: "payload_properties.txt", Size: 154, Offset: 817269376},
+			{Filename: "apex_info.pb", Size: 925, Offset: 1569},
+			{Filename: "care_map.pb", Size: 118, Offset: 2541},
+			{Filename: "metadata", Size: 653, Offset: 69},
+			{Filename: "metadata.pb", Size: 731, Offset: 790},
+		},
+		SwitchSlotAllowed: true,
+		InstallType:       OTAStreaming,
+	}
+}
+
+func TestOTAMetadataMatchesLegacyString(t *testing.T) {
+	update := legacyOTAUpdate()
+	if err := update.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	got, err := update.metadata()
+	if err != nil {
+		t.Fatalf("metadata() error = %v", err)
+	}
+	if got != legacyOTAMetadata {
+		t.Errorf("metadata() =\n%s\nwant\n%s", got, legacyOTAMetadata)
+	}
+}
+
+func TestOTAUpdateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(u *OTAUpdate)
+		wantErr string
+	}{
+		{"valid streaming", func(u *OTAUpdate) {}, ""},
+		{"non streaming without property files", func(u *OTAUpdate) {
+			u.InstallType = OTANonStreaming
+			u.PropertyFiles = nil
+		}, ""},
+		{"streaming without property files", func(u *OTAUpdate) {
+			u.PropertyFiles = nil
+		}, "require the payload.bin property file"},
+		{"streaming without payload properties", func(u *OTAUpdate) {
+			u.PropertyFiles = u.PropertyFiles[:2]
+		}, "require the payload_properties.txt property file"},
+		{"unknown install type", func(u *OTAUpdate) { u.InstallType = "FULL" }, `unknown install type "FULL"`},
+		{"missing name", func(u *OTAUpdate) { u.Name = "" }, "name is required"},
+		{"http URL", func(u *OTAUpdate) { u.URL = "http://ota.esper.cloud/full.zip" }, "absolute https URL"},
+		{"relative URL", func(u *OTAUpdate) { u.URL = "/full.zip" }, "absolute https URL"},
+		{"malformed URL", func(u *OTAUpdate) { u.URL = "https://%zz" }, "absolute https URL"},
+		{"hex file hash", func(u *OTAUpdate) {
+			u.FileHash = "c0f61e7c57d37bcbe79dca4cdd1147175452c40d842ccea163b4169f7b00a450"
+		}, "file hash must be"},
+		{"short metadata hash", func(u *OTAUpdate) { u.MetadataHash = "qbr5WBzNRvv8" }, "metadata hash must be"},
+		{"zero size", func(u *OTAUpdate) { u.MetadataSize = 0 }, "sizes must be positive"},
+		{"negative offset", func(u *OTAUpdate) {
+			u.PropertyFiles = append(u.PropertyFiles, OTAPropertyFile{Filename: "extra", Offset: -1})
+		}, "invalid property file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := legacyOTAUpdate()
+			tt.modify(&update)
+			err := update.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ArgType is the JSON type expected for a command argument
+type ArgType int
+
+const (
+	ArgString ArgType = iota
+	ArgInt
+	ArgBool
+	ArgList
+	ArgObject
+)
+
+func (t ArgType) String() string {
+	switch t {
+	case ArgString:
+		return "string"
+	case ArgInt:
+		return "integer"
+	case ArgBool:
+		return "boolean"
+	case ArgList:
+		return "list"
+	case ArgObject:
+		return "object"
+	}
+	return fmt.Sprintf("ArgType(%d)", int(t))
+}
+
+// ArgSpec describes one argument of a command
+type ArgSpec struct {
+	Name     string
+	Type     ArgType
+	Required bool
+	Min, Max *int                    // Inclusive bounds for ArgInt
+	OneOf    []string                // Allowed values for ArgString
+	Check    func(interface{}) error // Extra check run after the type check
+}
+
+// CommandSpec describes the arguments accepted by a command
+type CommandSpec struct {
+	Args       []ArgSpec
+	AllowExtra bool // Accept arguments not listed in Args
+}
+
+var (
+	registryMu      sync.RWMutex
+	commandRegistry = map[Command]CommandSpec{
+		CommandAddToWhitelist:      {Args: []ArgSpec{{Name: "package_names", Type: ArgList, Required: true}}},
+		CommandAddWifiAP:           {Args: []ArgSpec{{Name: "wifi_access_points", Type: ArgList, Required: true}}},
+		CommandClearAppData:        {Args: []ArgSpec{packageNameArg}},
+		CommandInstall:             {Args: []ArgSpec{{Name: "app_version", Type: ArgString, Required: true}}},
+		CommandLock:                {},
+		CommandReboot:              {},
+		CommandRemoveFromWhitelist: {Args: []ArgSpec{{Name: "package_names", Type: ArgList, Required: true}}},
+		CommandRemoveWifiAP:        {Args: []ArgSpec{{Name: "wifi_access_points", Type: ArgList, Required: true}}},
+		CommandSetAppPermission: {Args: []ArgSpec{
+			packageNameArg,
+			{Name: "permission", Type: ArgString, Required: true},
+			{Name: "grant_state", Type: ArgString, Required: true, OneOf: []string{"GRANT", "DENY", "PROMPT"}},
+		}},
+		CommandSetAppState: {Args: []ArgSpec{
+			packageNameArg,
+			{Name: "app_state", Type: ArgString, Required: true, OneOf: appStateNames},
+		}},
+		CommandSetBluetoothState:  {Args: []ArgSpec{{Name: "bluetooth_state", Type: ArgBool, Required: true}}},
+		CommandSetBrightnessScale: {Args: []ArgSpec{{Name: "brightness_value", Type: ArgInt, Required: true, Min: bound(1), Max: bound(100)}}},
+		CommandSetDeviceLockdownState: {Args: []ArgSpec{
+			{Name: "state", Type: ArgString, Required: true, OneOf: []string{"LOCKED", "UNLOCKED"}},
+			{Name: "message", Type: ArgString},
+		}},
+		CommandSetGPSState:      {Args: []ArgSpec{{Name: "gps_state", Type: ArgInt, Required: true, Min: bound(int(GPSHighAccuracy)), Max: bound(int(GPSOn))}}},
+		CommandSetKioskApp:      {Args: []ArgSpec{packageNameArg}},
+		CommandSetNewPolicy:     {Args: []ArgSpec{{Name: "policy_url", Type: ArgString, Required: true}}},
+		CommandSetRotationState: {Args: []ArgSpec{{Name: "rotate_state", Type: ArgInt, Required: true, Min: bound(int(RotationAuto)), Max: bound(int(RotationLandscape))}}},
+		CommandSetScreenOffTimeout: {Args: []ArgSpec{{Name: "screen_off_timeout", Type: ArgInt, Required: true, Check: func(v interface{}) error {
+			if timeout, _ := asInt(v); timeout != -1 && (timeout < 5000 || timeout > 1800000) {
+				return errors.New("must be -1 or between 5000 and 1800000")
+			}
+			return nil
+		}}}},
+		CommandSetStreamVolume: {Args: []ArgSpec{
+			{Name: "stream", Type: ArgInt, Required: true, Min: bound(int(StreamRing)), Max: bound(int(StreamMusic))},
+			{Name: "volume_level", Type: ArgInt, Required: true, Min: bound(0), Max: bound(100)},
+		}},
+		CommandSetTimezone:        {Args: []ArgSpec{{Name: "timezone_string", Type: ArgString, Required: true}}},
+		CommandSetWifiState:       {Args: []ArgSpec{{Name: "wifi_state", Type: ArgBool, Required: true}}},
+		CommandUninstall:          {Args: []ArgSpec{packageNameArg}},
+		CommandUpdateDeviceConfig: {AllowExtra: true, Args: []ArgSpec{{Name: "custom_settings_config", Type: ArgObject}}},
+		CommandUpdateHeartbeat:    {Args: []ArgSpec{{Name: "heartbeat_interval", Type: ArgInt, Min: bound(60), Max: bound(86400)}}},
+		CommandUpdateLatestDPC:    {},
+		CommandWipe:               {},
+		CommandResetLockscreenPassword: {Args: []ArgSpec{
+			{Name: "new_lockscreen_password", Type: ArgString, Required: true},
+		}},
+		CommandCaptureScreenshot: {Args: []ArgSpec{{Name: "tag", Type: ArgString}}},
+		CommandUpdateBlueprint:   {},
+		CommandNotifyDevice: {Args: []ArgSpec{
+			{Name: "title", Type: ArgString, Required: true},
+			{Name: "message", Type: ArgString, Required: true},
+			{Name: "url", Type: ArgString},
+		}},
+		CommandSetDeviceLanguage: {Args: []ArgSpec{{Name: "locale", Type: ArgString, Required: true}}},
+		CommandSetEthernetSettings: {Args: []ArgSpec{
+			{Name: "ip_assignment", Type: ArgString, Required: true, OneOf: []string{"DHCP", "STATIC"}},
+			{Name: "ip_address", Type: ArgString},
+			{Name: "prefix_length", Type: ArgInt, Min: bound(1), Max: bound(128)},
+			{Name: "gateway", Type: ArgString},
+			{Name: "dns_servers", Type: ArgList},
+			{Name: "proxy", Type: ArgObject},
+		}},
+		CommandSetStaticIP: {Args: []ArgSpec{
+			{Name: "ip_address", Type: ArgString, Required: true},
+			{Name: "prefix_length", Type: ArgInt, Required: true, Min: bound(1), Max: bound(128)},
+			{Name: "gateway", Type: ArgString, Required: true},
+			{Name: "dns_servers", Type: ArgList},
+			{Name: "proxy", Type: ArgObject},
+		}},
+		CommandBeepDevice: {Args: []ArgSpec{{Name: "duration", Type: ArgString, Required: true}}},
+		CommandSetAppNotifications: {Args: []ArgSpec{
+			packageNameArg,
+			{Name: "notification_state", Type: ArgString, Required: true, OneOf: []string{"ENABLE", "DISABLE"}},
+		}},
+		CommandUseOnlySavedWifiAP: {Args: []ArgSpec{{Name: "use_only_saved_wifi_ap", Type: ArgBool, Required: true}}},
+		CommandConverge:           {},
+	}
+)
+
+var packageNameArg = ArgSpec{Name: "package_name", Type: ArgString, Required: true, Check: func(v interface{}) error {
+	return validatePackageName(fmt.Sprint(v))
+}}
+
+func bound(n int) *int {
+	return &n
+}
+
+// RegisterCommand adds or replaces the argument spec of a command, so
+// commands newer than this SDK can be validated and sent
+func RegisterCommand(command Command, spec CommandSpec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	commandRegistry[command] = spec
+}
+
+// LookupCommand returns the argument spec of a registered command
+func LookupCommand(command Command) (CommandSpec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	spec, ok := commandRegistry[command]
+	return spec, ok
+}
+
+// ValidateCommandArgs checks args against the registered spec of command and
+// returns a *ValidationError listing every invalid field
+func ValidateCommandArgs(command Command, args map[string]interface{}) error {
+	verr := &ValidationError{Command: command}
+	validateArgs(verr, command, args)
+	return verr.errOrNil()
+}
+
+// validateArgs records every problem with args in verr
+func validateArgs(verr *ValidationError, command Command, args map[string]interface{}) {
+	spec, ok := LookupCommand(command)
+	if !ok {
+		verr.add("command", "unknown command %q, register it with RegisterCommand", command)
+		return
+	}
+
+	known := make(map[string]bool, len(spec.Args))
+	for _, arg := range spec.Args {
+		known[arg.Name] = true
+		field := "command_args." + arg.Name
+
+		value, present := args[arg.Name]
+		if !present || value == nil {
+			if arg.Required {
+				verr.add(field, "is required")
+			}
+			continue
+		}
+		if err := arg.validate(value); err != nil {
+			verr.add(field, "%v", err)
+		}
+	}
+
+	if spec.AllowExtra {
+		return
+	}
+	var unknown []string
+	for name := range args {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		verr.add("command_args."+name, "is not an argument of %s", command)
+	}
+}
+
+// validate checks the type, bounds and allowed values of a single argument
+func (a ArgSpec) validate(value interface{}) error {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return fmt.Errorf("must be of type %s", a.Type)
+		}
+		rv = rv.Elem()
+	}
+
+	typeOK := false
+	switch a.Type {
+	case ArgString:
+		typeOK = rv.Kind() == reflect.String
+	case ArgInt:
+		_, typeOK = asInt(rv.Interface())
+	case ArgBool:
+		typeOK = rv.Kind() == reflect.Bool
+	case ArgList:
+		typeOK = rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+	case ArgObject:
+		typeOK = rv.Kind() == reflect.Map || rv.Kind() == reflect.Struct
+	}
+	if !typeOK {
+		return fmt.Errorf("must be of type %s, got %T", a.Type, value)
+	}
+	if a.Type == ArgString && a.Required && strings.TrimSpace(rv.String()) == "" {
+		return errors.New("must not be empty")
+	}
+
+	if a.Type == ArgInt {
+		n, _ := asInt(rv.Interface())
+		if (a.Min != nil && n < *a.Min) || (a.Max != nil && n > *a.Max) {
+			return fmt.Errorf("must be between %s and %s", boundString(a.Min), boundString(a.Max))
+		}
+	}
+	if a.Type == ArgList && rv.Len() == 0 && a.Required {
+		return errors.New("must not be empty")
+	}
+	if len(a.OneOf) > 0 && !slices.Contains(a.OneOf, rv.String()) {
+		return fmt.Errorf("must be one of %v", a.OneOf)
+	}
+	if a.Check != nil {
+		return a.Check(rv.Interface())
+	}
+	return nil
+}
+
+// asInt converts any Go integer, or a float holding a whole number as
+// produced by decoding JSON, into an int
+func asInt(value interface{}) (int, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f == math.Trunc(f) {
+			return int(f), true
+		}
+	}
+	return 0, false
+}
+
+// jsonTypeName names the JSON type a Go kind is decoded from
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return ArgString.String()
+	case reflect.Bool:
+		return ArgBool.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ArgInt.String()
+	case reflect.Slice, reflect.Array:
+		return ArgList.String()
+	case reflect.Map, reflect.Struct, reflect.Pointer:
+		return ArgObject.String()
+	}
+	return kind.String()
+}
+
+func boundString(n *int) string {
+	if n == nil {
+		return "unbounded"
+	}
+	return fmt.Sprint(*n)
+}
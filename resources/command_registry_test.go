@@ -0,0 +1,187 @@
+package resources
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// fieldNames returns the fields listed by err, which must be a *ValidationError
+func fieldNames(t *testing.T, err error) []string {
+	t.Helper()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error %v is not a *ValidationError", err)
+	}
+	var fields []string
+	for _, field := range verr.Fields {
+		fields = append(fields, field.Field)
+	}
+	return fields
+}
+
+func TestValidateCommandBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       map[string]interface{}
+		wantFields []string
+	}{
+		{
+			name: "valid",
+			body: map[string]interface{}{
+				"command_type": "DEVICE",
+				"devices":      []string{"d1"},
+				"command":      "SET_BRIGHTNESS_SCALE",
+				"command_args": map[string]interface{}{"brightness_value": 50},
+			},
+		},
+		{
+			name:       "no target",
+			body:       map[string]interface{}{"command": "REBOOT"},
+			wantFields: []string{"command_type"},
+		},
+		{
+			name:       "device command without devices",
+			body:       map[string]interface{}{"command_type": CommandTypeDevice, "command": CommandReboot},
+			wantFields: []string{"command_type"},
+		},
+		{
+			name:       "group command",
+			body:       map[string]interface{}{"command_type": "GROUP", "groups": []string{"g"}, "command": "REBOOT"},
+			wantFields: nil,
+		},
+		{
+			name: "window schedule without args",
+			body: map[string]interface{}{
+				"command_type": "DEVICE", "devices": []string{"d"}, "command": "REBOOT", "schedule": "WINDOW",
+			},
+			wantFields: []string{"schedule_args"},
+		},
+		{
+			name:       "devices of the wrong type",
+			body:       map[string]interface{}{"command_type": "DEVICE", "devices": "d1", "command": "REBOOT"},
+			wantFields: []string{"devices"},
+		},
+		{
+			name:       "args of the wrong type",
+			body:       map[string]interface{}{"command_type": "DEVICE", "devices": []string{"d"}, "command": "REBOOT", "command_args": "x"},
+			wantFields: []string{"command_args"},
+		},
+		{
+			name: "type errors do not hide other fields",
+			body: map[string]interface{}{
+				"command_type": "DEVICE",
+				"devices":      "d",
+				"command":      "SET_BRIGHTNESS_SCALE",
+				"command_args": map[string]interface{}{"brightness_value": 500},
+			},
+			wantFields: []string{"devices", "command_args.brightness_value"},
+		},
+		{
+			name: "several type errors",
+			body: map[string]interface{}{
+				"command_type":   "DYNAMIC",
+				"dynamic_filter": map[string]interface{}{"tags": "t"},
+				"command":        "REBOOT",
+				"schedule_args":  []string{"x"},
+			},
+			wantFields: []string{"dynamic_filter.tags", "schedule_args"},
+		},
+		{
+			name: "unknown device type",
+			body: map[string]interface{}{
+				"command_type": "GROUP", "groups": []string{"g"}, "command": "REBOOT", "device_type": "bogus",
+			},
+			wantFields: []string{"device_type"},
+		},
+		{
+			name: "active device type",
+			body: map[string]interface{}{
+				"command_type": "GROUP", "groups": []string{"g"}, "command": "REBOOT", "device_type": "active",
+			},
+		},
+		{
+			name:       "empty device ID",
+			body:       map[string]interface{}{"command_type": "DEVICE", "devices": []string{""}, "command": "REBOOT"},
+			wantFields: []string{"command_type"},
+		},
+		{
+			name:       "empty group ID",
+			body:       map[string]interface{}{"command_type": "GROUP", "groups": []string{"g", ""}, "command": "REBOOT"},
+			wantFields: []string{"command_type"},
+		},
+		{
+			name: "every problem is listed",
+			body: map[string]interface{}{
+				"command":      "SET_STREAM_VOLUME",
+				"schedule":     "SOMETIME",
+				"command_args": map[string]interface{}{"stream": 9, "volume": 10},
+			},
+			wantFields: []string{"command_type", "schedule", "command_args.stream", "command_args.volume_level", "command_args.volume"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCommandBody(tt.body)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("validateCommandBody() error = %v", err)
+				}
+				return
+			}
+			if got := fieldNames(t, err); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("fields = %v, want %v (%v)", got, tt.wantFields, err)
+			}
+		})
+	}
+}
+
+func TestValidateCommandArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     Command
+		args        map[string]interface{}
+		wantFields  []string
+		wantMessage string
+	}{
+		{"valid notification", CommandNotifyDevice, map[string]interface{}{"title": "t", "message": "m"}, nil, ""},
+		{
+			name:        "empty required strings",
+			command:     CommandNotifyDevice,
+			args:        map[string]interface{}{"title": "", "message": " "},
+			wantFields:  []string{"command_args.title", "command_args.message"},
+			wantMessage: "invalid NOTIFY_DEVICE command: command_args.title: must not be empty; command_args.message: must not be empty",
+		},
+		{"empty optional string", CommandCaptureScreenshot, map[string]interface{}{"tag": ""}, nil, ""},
+		{
+			name:        "wrong type",
+			command:     CommandSetBrightnessScale,
+			args:        map[string]interface{}{"brightness_value": "50"},
+			wantFields:  []string{"command_args.brightness_value"},
+			wantMessage: "invalid SET_BRIGHTNESS_SCALE command: command_args.brightness_value: must be of type integer, got string",
+		},
+		{"JSON number", CommandSetBrightnessScale, map[string]interface{}{"brightness_value": 50.0}, nil, ""},
+		{"fractional number", CommandSetBrightnessScale, map[string]interface{}{"brightness_value": 50.5}, []string{"command_args.brightness_value"}, ""},
+		{"out of range", CommandSetGPSState, map[string]interface{}{"gps_state": 5}, []string{"command_args.gps_state"}, ""},
+		{"not one of", CommandSetAppState, map[string]interface{}{"package_name": "com.example", "app_state": "SHOWN"}, []string{"command_args.app_state"}, ""},
+		{"missing and unknown", CommandSetTimezone, map[string]interface{}{"timezone": "UTC"}, []string{"command_args.timezone_string", "command_args.timezone"}, ""},
+		{"unknown command", Command("SELF_DESTRUCT"), nil, []string{"command"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommandArgs(tt.command, tt.args)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("ValidateCommandArgs() error = %v", err)
+				}
+				return
+			}
+			if got := fieldNames(t, err); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("fields = %v, want %v (%v)", got, tt.wantFields, err)
+			}
+			if tt.wantMessage != "" && err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DeviceType restricts group and dynamic commands by device activity
+type DeviceType string
+
+const (
+	DeviceTypeActive   DeviceType = "active"   // Only devices that are online and enrolled
+	DeviceTypeInactive DeviceType = "inactive" // Only devices that are offline or disabled
+	DeviceTypeAll      DeviceType = "all"      // Every device regardless of activity
+)
+
+// CommandRequest is the body of a command submitted to the commands endpoint
+type CommandRequest struct {
+	CommandType   CommandType            `json:"command_type"`
+	Command       Command                `json:"command"`
+	Devices       []string               `json:"devices,omitempty"`
+	Groups        []string               `json:"groups,omitempty"`
+	DynamicFilter *DynamicFilter         `json:"dynamic_filter,omitempty"`
+	DeviceType    DeviceType             `json:"device_type,omitempty"`
+	Args          map[string]interface{} `json:"command_args,omitempty"`
+	Schedule      ScheduleType           `json:"schedule,omitempty"`
+	ScheduleArgs  map[string]interface{} `json:"schedule_args,omitempty"`
+}
+
+// Validate checks the target, schedule and arguments of the request against
+// the command registry. It returns a *ValidationError listing every invalid field.
+func (r *CommandRequest) Validate() error {
+	if r == nil {
+		return errors.New("command request is nil")
+	}
+
+	verr := &ValidationError{Command: r.Command}
+	r.validate(verr)
+	return verr.errOrNil()
+}
+
+// validate records every problem with the request in verr. Fields that
+// already have a problem recorded, such as a JSON type mismatch, are skipped.
+func (r *CommandRequest) validate(verr *ValidationError) {
+	if !verr.has("command_type", "devices", "groups", "dynamic_filter") {
+		if err := r.Target().Validate(); err != nil {
+			verr.add("command_type", "%v", err)
+		}
+	}
+
+	if !verr.has("device_type") {
+		switch r.DeviceType {
+		case "", DeviceTypeActive, DeviceTypeInactive, DeviceTypeAll:
+		default:
+			verr.add("device_type", "unknown device type %q", r.DeviceType)
+		}
+	}
+
+	if !verr.has("schedule", "schedule_args") {
+		switch r.Schedule {
+		case "", ScheduleImmediate:
+		case ScheduleWindow, ScheduleRecurring:
+			if len(r.ScheduleArgs) == 0 {
+				verr.add("schedule_args", "are required for a %s schedule", r.Schedule)
+			}
+		default:
+			verr.add("schedule", "unknown schedule %q", r.Schedule)
+		}
+	}
+
+	switch {
+	case verr.has("command"):
+	case r.Command == "":
+		verr.add("command", "is required")
+	case !verr.has("command_args"):
+		validateArgs(verr, r.Command, r.Args)
+	}
+}
+
+// validateCommandBody decodes a raw command body the way the API will and
+// validates it like a CommandRequest. A field of the wrong JSON type is
+// reported and dropped so the remaining fields are still validated.
+func validateCommandBody(body map[string]interface{}) error {
+	body = maps.Clone(body)
+	verr := &ValidationError{}
+	var req CommandRequest
+	for {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal command body: %w", err)
+		}
+
+		req = CommandRequest{}
+		err = json.Unmarshal(data, &req)
+		if err == nil {
+			break
+		}
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field == "" {
+			return fmt.Errorf("invalid command body: %w", err)
+		}
+		verr.add(typeErr.Field, "must be of type %s, got a JSON %s", jsonTypeName(typeErr.Type.Kind()), typeErr.Value)
+		key, _, _ := strings.Cut(typeErr.Field, ".")
+		delete(body, key)
+	}
+
+	verr.Command = req.Command
+	req.validate(verr)
+	return verr.errOrNil()
+}
+
+// Target returns the devices, groups or dynamic filter the request is aimed at
+func (r *CommandRequest) Target() Target {
+	return Target{
+		Type:    r.CommandType,
+		Devices: r.Devices,
+		Groups:  r.Groups,
+		Filter:  r.DynamicFilter,
+	}
+}
+
+// CommandRequestBuilder builds a CommandRequest fluently, for example:
+//
+//	req, err := NewCommandRequest(CommandSetKioskApp).
+//		ForDevices(deviceID).
+//		WithArg("package_name", "com.example.kiosk").
+//		Build()
+type CommandRequestBuilder struct {
+	req      CommandRequest
+	schedule *Schedule
+}
+
+// NewCommandRequest starts building an immediate request for command
+func NewCommandRequest(command Command) *CommandRequestBuilder {
+	return &CommandRequestBuilder{req: CommandRequest{
+		Command:  command,
+		Schedule: ScheduleImmediate,
+	}}
+}
+
+// ForDevices targets the request at the given devices. Repeated calls add
+// devices; a previous group or dynamic target is replaced.
+func (b *CommandRequestBuilder) ForDevices(devices ...string) *CommandRequestBuilder {
+	if b.req.CommandType != CommandTypeDevice {
+		DeviceTarget().apply(&b.req)
+	}
+	b.req.Devices = append(b.req.Devices, devices...)
+	return b
+}
+
+// ForGroups targets the request at every device in the given groups. Repeated
+// calls add groups; a previous device or dynamic target is replaced.
+func (b *CommandRequestBuilder) ForGroups(groups ...string) *CommandRequestBuilder {
+	if b.req.CommandType != CommandTypeGroup {
+		GroupTarget().apply(&b.req)
+	}
+	b.req.Groups = append(b.req.Groups, groups...)
+	return b
+}
+
+// ForTarget aims the request at target, replacing any previous target
+func (b *CommandRequestBuilder) ForTarget(target Target) *CommandRequestBuilder {
+	target.apply(&b.req)
+	return b
+}
+
+// ForDynamic targets every device matching filter
+func (b *CommandRequestBuilder) ForDynamic(filter DynamicFilter) *CommandRequestBuilder {
+	return b.ForTarget(DynamicTarget(filter))
+}
+
+// WithDeviceType restricts group and dynamic requests to a device type
+func (b *CommandRequestBuilder) WithDeviceType(deviceType DeviceType) *CommandRequestBuilder {
+	b.req.DeviceType = deviceType
+	return b
+}
+
+// WithArg sets a single command argument
+func (b *CommandRequestBuilder) WithArg(key string, value interface{}) *CommandRequestBuilder {
+	if b.req.Args == nil {
+		b.req.Args = map[string]interface{}{}
+	}
+	b.req.Args[key] = value
+	return b
+}
+
+// WithArgs merges args into the command arguments
+func (b *CommandRequestBuilder) WithArgs(args map[string]interface{}) *CommandRequestBuilder {
+	for key, value := range args {
+		b.WithArg(key, value)
+	}
+	return b
+}
+
+// WithSchedule sets when the command runs
+func (b *CommandRequestBuilder) WithSchedule(scheduleType ScheduleType, scheduleArgs map[string]interface{}) *CommandRequestBuilder {
+	b.req.Schedule = scheduleType
+	b.req.ScheduleArgs = scheduleArgs
+	b.schedule = nil
+	return b
+}
+
+// Scheduled sets when the command runs from a typed Schedule
+func (b *CommandRequestBuilder) Scheduled(schedule Schedule) *CommandRequestBuilder {
+	b.schedule = &schedule
+	schedule.apply(&b.req)
+	return b
+}
+
+// Build validates and returns the request. The request does not share any
+// slices or maps with the builder, so the builder can be reused.
+func (b *CommandRequestBuilder) Build() (*CommandRequest, error) {
+	req := b.req
+	req.Devices = slices.Clone(req.Devices)
+	req.Groups = slices.Clone(req.Groups)
+	req.Args = maps.Clone(req.Args)
+	req.ScheduleArgs = maps.Clone(req.ScheduleArgs)
+	if b.schedule != nil {
+		if err := b.schedule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
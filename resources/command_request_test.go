@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommandRequestBuilderTargets(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     *CommandRequestBuilder
+		wantType    CommandType
+		wantDevices []string
+		wantGroups  []string
+		wantFilter  bool
+	}{
+		{
+			name:        "devices accumulate",
+			builder:     NewCommandRequest(CommandReboot).ForDevices("d1").ForDevices("d2"),
+			wantType:    CommandTypeDevice,
+			wantDevices: []string{"d1", "d2"},
+		},
+		{
+			name:        "devices replace groups",
+			builder:     NewCommandRequest(CommandReboot).ForGroups("g").ForDevices("d"),
+			wantType:    CommandTypeDevice,
+			wantDevices: []string{"d"},
+		},
+		{
+			name:       "groups replace a dynamic filter",
+			builder:    NewCommandRequest(CommandReboot).ForDynamic(DynamicFilter{Tags: []string{"t"}}).ForGroups("g"),
+			wantType:   CommandTypeGroup,
+			wantGroups: []string{"g"},
+		},
+		{
+			name:       "dynamic filter replaces devices",
+			builder:    NewCommandRequest(CommandReboot).ForDevices("d").ForDynamic(DynamicFilter{Tags: []string{"t"}}),
+			wantType:   CommandTypeDynamic,
+			wantFilter: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if req.CommandType != tt.wantType ||
+				!slices.Equal(req.Devices, tt.wantDevices) ||
+				!slices.Equal(req.Groups, tt.wantGroups) ||
+				(req.DynamicFilter != nil) != tt.wantFilter {
+				t.Errorf("Build() = %s devices %v groups %v filter %v", req.CommandType, req.Devices, req.Groups, req.DynamicFilter)
+			}
+		})
+	}
+}
+
+func TestTargetValidateRejectsMixedTargets(t *testing.T) {
+	tests := []struct {
+		name   string
+		target Target
+	}{
+		{"groups on device target", Target{Type: CommandTypeDevice, Devices: []string{"d"}, Groups: []string{"g"}}},
+		{"devices on group target", Target{Type: CommandTypeGroup, Groups: []string{"g"}, Devices: []string{"d"}}},
+		{"filter on group target", Target{Type: CommandTypeGroup, Groups: []string{"g"}, Filter: &DynamicFilter{Tags: []string{"t"}}}},
+		{"devices on dynamic target", Target{Type: CommandTypeDynamic, Filter: &DynamicFilter{Tags: []string{"t"}}, Devices: []string{"d"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.target.Validate(); err == nil {
+				t.Error("Validate() succeeded")
+			}
+		})
+	}
+}
+
+func TestCommandRequestBuilderDoesNotAlias(t *testing.T) {
+	ids := make([]string, 1, 4)
+	ids[0] = "a"
+	builder := NewCommandRequest(CommandSetBrightnessScale).
+		ForTarget(DeviceTarget(ids...)).
+		ForDevices("b").
+		WithArg("brightness_value", 50)
+
+	if got := ids[:2]; got[1] != "" {
+		t.Errorf("ForDevices wrote into the caller's slice: %v", got)
+	}
+
+	first, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	second, err := builder.ForDevices("c").WithArg("brightness_value", 80).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !slices.Equal(first.Devices, []string{"a", "b"}) || first.Args["brightness_value"] != 50 {
+		t.Errorf("first request changed after reusing the builder: %v %v", first.Devices, first.Args)
+	}
+	if !slices.Equal(second.Devices, []string{"a", "b", "c"}) || second.Args["brightness_value"] != 80 {
+		t.Errorf("second request = %v %v", second.Devices, second.Args)
+	}
+}
@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CommandResponse is a command request as returned by the commands API
+type CommandResponse struct {
+	ID           string                 `json:"id"`
+	Enterprise   string                 `json:"enterprise"`
+	CommandType  CommandType            `json:"command_type"`
+	Command      Command                `json:"command"`
+	Args         map[string]interface{} `json:"command_args"`
+	Devices      []string               `json:"devices"`
+	Groups       []string               `json:"groups"`
+	DeviceType   DeviceType             `json:"device_type"`
+	State        CommandState           `json:"state"`
+	IssuedBy     int                    `json:"issued_by"`
+	Schedule     ScheduleType           `json:"schedule"`
+	ScheduleArgs map[string]interface{} `json:"schedule_args"`
+	Status       []CommandStateCount    `json:"status"`
+	CreatedOn    time.Time              `json:"created_on"`
+	UpdatedOn    time.Time              `json:"updated_on"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// CommandStateCount is the number of targeted devices in a given state
+type CommandStateCount struct {
+	State CommandState `json:"state"`
+	Total int          `json:"total"`
+}
+
+// UnmarshalJSON decodes a command response and keeps a copy of the raw JSON
+func (r *CommandResponse) UnmarshalJSON(data []byte) error {
+	type plain CommandResponse
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	r.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
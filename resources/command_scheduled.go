@@ -0,0 +1,198 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ScheduledCommandStatus is the lifecycle status of a scheduled command
+type ScheduledCommandStatus string
+
+const (
+	ScheduledCommandActive    ScheduledCommandStatus = "ACTIVE"
+	ScheduledCommandCompleted ScheduledCommandStatus = "COMPLETED"
+	ScheduledCommandCancelled ScheduledCommandStatus = "CANCELLED"
+)
+
+// ScheduledCommand is a window or recurring command waiting to run
+type ScheduledCommand struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	CommandType  CommandType            `json:"command_type"`
+	Command      Command                `json:"command"`
+	Args         map[string]interface{} `json:"command_args"`
+	Devices      []string               `json:"devices"`
+	Groups       []string               `json:"groups"`
+	Schedule     ScheduleType           `json:"schedule"`
+	ScheduleArgs map[string]interface{} `json:"schedule_args"`
+	Status       ScheduledCommandStatus `json:"status"`
+	NextRun      *time.Time             `json:"next_run"`
+	IssuedBy     int                    `json:"issued_by"`
+	CreatedOn    time.Time              `json:"created_on"`
+	UpdatedOn    time.Time              `json:"updated_on"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a scheduled command and keeps a copy of the raw JSON
+func (s *ScheduledCommand) UnmarshalJSON(data []byte) error {
+	type plain ScheduledCommand
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// ScheduledCommandList is a single page of scheduled commands
+type ScheduledCommandList = Page[ScheduledCommand]
+
+// ScheduledCommandFilter narrows ListScheduled. The zero value lists everything.
+type ScheduledCommandFilter struct {
+	Command  Command
+	Schedule ScheduleType // ScheduleWindow or ScheduleRecurring
+	Status   ScheduledCommandStatus
+	Limit    int
+	Offset   int
+}
+
+// Validate checks the filter for invalid values
+func (f *ScheduledCommandFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	var errs []error
+	switch f.Schedule {
+	case "", ScheduleWindow, ScheduleRecurring:
+	default:
+		errs = append(errs, fmt.Errorf("cannot list scheduled commands with schedule %q", f.Schedule))
+	}
+	switch f.Status {
+	case "", ScheduledCommandActive, ScheduledCommandCompleted, ScheduledCommandCancelled:
+	default:
+		errs = append(errs, fmt.Errorf("unknown scheduled command status %q", f.Status))
+	}
+	errs = append(errs, validatePageParams(f.Limit, f.Offset)...)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid scheduled command filter: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Values validates the filter and encodes it as query parameters
+func (f *ScheduledCommandFilter) Values() (url.Values, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	queryParams := url.Values{}
+	if f == nil {
+		return queryParams, nil
+	}
+
+	setIfNotEmpty(queryParams, "command", string(f.Command))
+	setIfNotEmpty(queryParams, "schedule", string(f.Schedule))
+	setIfNotEmpty(queryParams, "status", string(f.Status))
+	setPageParams(queryParams, f.Limit, f.Offset)
+	return queryParams, nil
+}
+
+// ListScheduled lists a page of window and recurring commands
+func (c *Commands) ListScheduled(filter *ScheduledCommandFilter) (*ScheduledCommandList, error) {
+	return c.ListScheduledContext(context.Background(), filter)
+}
+
+// ListScheduledContext is like ListScheduled but carries ctx for cancellation and deadlines
+func (c *Commands) ListScheduledContext(ctx context.Context, filter *ScheduledCommandFilter) (*ScheduledCommandList, error) {
+	query, err := filter.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	var list ScheduledCommandList
+	if err := c.Request.Do(ctx, http.MethodGet, c.scheduledEndpoint(""), query, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// AllScheduled iterates over every scheduled command matching filter,
+// following the API's next links page by page. The filter's Limit sets the page size.
+func (c *Commands) AllScheduled(ctx context.Context, filter *ScheduledCommandFilter) iter.Seq2[ScheduledCommand, error] {
+	query, err := filter.Values()
+	if err != nil {
+		return failed[ScheduledCommand](err)
+	}
+	return paginate[ScheduledCommand](ctx, c.Request, c.scheduledEndpoint(""), query)
+}
+
+// ListAllScheduled collects every scheduled command matching filter into a slice
+func (c *Commands) ListAllScheduled(ctx context.Context, filter *ScheduledCommandFilter) ([]ScheduledCommand, error) {
+	return collect(c.AllScheduled(ctx, filter))
+}
+
+// GetScheduled fetches a scheduled command by ID
+func (c *Commands) GetScheduled(scheduleID string) (*ScheduledCommand, error) {
+	return c.GetScheduledContext(context.Background(), scheduleID)
+}
+
+// GetScheduledContext is like GetScheduled but carries ctx for cancellation and deadlines
+func (c *Commands) GetScheduledContext(ctx context.Context, scheduleID string) (*ScheduledCommand, error) {
+	var scheduled ScheduledCommand
+	if err := c.Request.Do(ctx, http.MethodGet, c.scheduledEndpoint(scheduleID), nil, nil, &scheduled); err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+// UpdateScheduled replaces the schedule of a scheduled command, e.g. to move a reboot window
+func (c *Commands) UpdateScheduled(scheduleID string, schedule Schedule) (*ScheduledCommand, error) {
+	return c.UpdateScheduledContext(context.Background(), scheduleID, schedule)
+}
+
+// UpdateScheduledContext is like UpdateScheduled but carries ctx for cancellation and deadlines
+func (c *Commands) UpdateScheduledContext(ctx context.Context, scheduleID string, schedule Schedule) (*ScheduledCommand, error) {
+	if schedule.Type == ScheduleImmediate {
+		return nil, errors.New("a scheduled command cannot be made immediate, cancel it and send a new command instead")
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"schedule":      string(schedule.Type),
+		"schedule_args": schedule.args(),
+	}
+	var scheduled ScheduledCommand
+	if err := c.Request.Do(ctx, http.MethodPatch, c.scheduledEndpoint(scheduleID), nil, body, &scheduled); err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+// CancelScheduled stops a scheduled command from running again
+func (c *Commands) CancelScheduled(scheduleID string) error {
+	return c.CancelScheduledContext(context.Background(), scheduleID)
+}
+
+// CancelScheduledContext is like CancelScheduled but carries ctx for cancellation and deadlines
+func (c *Commands) CancelScheduledContext(ctx context.Context, scheduleID string) error {
+	return c.Request.Do(ctx, http.MethodDelete, c.scheduledEndpoint(scheduleID), nil, nil, nil)
+}
+
+// scheduledEndpoint returns the scheduled commands collection, or a single item if scheduleID is set
+func (c *Commands) scheduledEndpoint(scheduleID string) string {
+	endpoint := fmt.Sprintf("/api/v0/enterprise/%s/command/scheduled/", c.Request.EnterpriseID)
+	if scheduleID != "" {
+		endpoint += scheduleID + "/"
+	}
+	return endpoint
+}
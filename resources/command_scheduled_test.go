@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestScheduledCommandFilterValues(t *testing.T) {
+	filter := &ScheduledCommandFilter{
+		Command:  CommandReboot,
+		Schedule: ScheduleRecurring,
+		Status:   ScheduledCommandActive,
+		Limit:    10,
+		Offset:   20,
+	}
+	want := url.Values{
+		"command":  {"REBOOT"},
+		"schedule": {"RECURRING"},
+		"status":   {"ACTIVE"},
+		"limit":    {"10"},
+		"offset":   {"20"},
+	}
+	got, err := filter.Values()
+	if err != nil {
+		t.Fatalf("Values() error = %v", err)
+	}
+	if got.Encode() != want.Encode() {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestScheduledCommandFilterValidateReportsEveryError(t *testing.T) {
+	filter := &ScheduledCommandFilter{Schedule: ScheduleImmediate, Status: "PAUSED", Limit: -1, Offset: -1}
+	err := filter.Validate()
+	if err == nil {
+		t.Fatal("Validate() succeeded")
+	}
+	for _, want := range []string{`schedule "IMMEDIATE"`, `status "PAUSED"`, "limit must be", "offset must not"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to mention %s", err, want)
+		}
+	}
+	if _, err := filter.Values(); err == nil {
+		t.Error("Values() accepted an invalid filter")
+	}
+}
@@ -0,0 +1,217 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CommandState represents the state of a command on a single device
+type CommandState string
+
+const (
+	CommandStateQueued       CommandState = "Command Queued"
+	CommandStateScheduled    CommandState = "Command Scheduled"
+	CommandStateInitiated    CommandState = "Command Initiated"
+	CommandStateAcknowledged CommandState = "Command Acknowledged"
+	CommandStateInProgress   CommandState = "Command In Progress"
+	CommandStateSuccess      CommandState = "Command Success"
+	CommandStateFailure      CommandState = "Command Failure"
+	CommandStateTimeout      CommandState = "Command TimeOut"
+	CommandStateCancelled    CommandState = "Command Cancelled"
+)
+
+// IsTerminal reports whether the command will not change state any more
+func (s CommandState) IsTerminal() bool {
+	switch s {
+	case CommandStateSuccess, CommandStateFailure, CommandStateTimeout, CommandStateCancelled:
+		return true
+	}
+	return false
+}
+
+// CommandStatus is the status of a command request on one device
+type CommandStatus struct {
+	ID        string       `json:"id"`
+	Request   string       `json:"request"`
+	Device    string       `json:"device"`
+	State     CommandState `json:"state"`
+	Reason    string       `json:"reason"`
+	CreatedOn time.Time    `json:"created_on"`
+	UpdatedOn time.Time    `json:"updated_on"`
+}
+
+// CommandResult aggregates the final per-device statuses of a command request
+type CommandResult struct {
+	RequestID string
+	Statuses  []CommandStatus
+}
+
+// Succeeded returns the devices on which the command succeeded
+func (r *CommandResult) Succeeded() []string {
+	return r.devicesIn(CommandStateSuccess)
+}
+
+// Failed returns the devices on which the command failed, timed out or was cancelled
+func (r *CommandResult) Failed() []string {
+	return r.devicesIn(CommandStateFailure, CommandStateTimeout, CommandStateCancelled)
+}
+
+// AllSucceeded reports whether the command succeeded on every device
+func (r *CommandResult) AllSucceeded() bool {
+	return len(r.Statuses) > 0 && len(r.Succeeded()) == len(r.Statuses)
+}
+
+func (r *CommandResult) devicesIn(states ...CommandState) []string {
+	var devices []string
+	for _, status := range r.Statuses {
+		for _, state := range states {
+			if status.State == state {
+				devices = append(devices, status.Device)
+				break
+			}
+		}
+	}
+	return devices
+}
+
+// ErrCommandScheduled is returned by WaitForCompletion for command requests
+// with a WINDOW or RECURRING schedule, which may not run for days or, when
+// recurring, never finish
+var ErrCommandScheduled = errors.New("command is scheduled")
+
+// emptyStatusPolls is the number of polls after which a group or dynamic
+// command request without any statuses or device counts is taken to target
+// no devices
+const emptyStatusPolls = 2
+
+// WaitOptions controls how WaitForCompletion polls for statuses
+type WaitOptions struct {
+	PollInterval    time.Duration // Delay before the second poll, defaults to 2s
+	MaxPollInterval time.Duration // Upper bound for the growing delay, defaults to 30s
+}
+
+// Get fetches a command request by ID
+func (c *Commands) Get(requestID string) (*CommandResponse, error) {
+	return c.GetContext(context.Background(), requestID)
+}
+
+// GetContext is like Get but carries ctx for cancellation and deadlines
+func (c *Commands) GetContext(ctx context.Context, requestID string) (*CommandResponse, error) {
+	var resp CommandResponse
+	if err := c.Request.Do(ctx, http.MethodGet, c.commandEndpoint(requestID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Statuses fetches the per-device statuses of a command request, following all pages
+func (c *Commands) Statuses(requestID string) ([]CommandStatus, error) {
+	return c.StatusesContext(context.Background(), requestID)
+}
+
+// StatusesContext is like Statuses but carries ctx for cancellation and deadlines
+func (c *Commands) StatusesContext(ctx context.Context, requestID string) ([]CommandStatus, error) {
+	endpoint := c.commandEndpoint(requestID) + "status/"
+	return collect(paginate[CommandStatus](ctx, c.Request, endpoint, nil))
+}
+
+// WaitForCompletion polls the statuses of a command request until every
+// targeted device reaches a terminal state, backing off between polls.
+// It returns early with ctx's error if ctx is cancelled or its deadline passes.
+//
+// The request is complete once there is a status for every targeted device:
+// the request's devices, or for group and dynamic targets the total of its
+// per-state device counts. A group or dynamic target that still reports no
+// devices and no statuses on the second poll yields an empty CommandResult.
+//
+// Offline devices keep a command queued until they reconnect, so ctx should
+// carry a deadline. Scheduled command requests fail with ErrCommandScheduled.
+func (c *Commands) WaitForCompletion(ctx context.Context, requestID string, opts *WaitOptions) (*CommandResult, error) {
+	interval, maxInterval := 2*time.Second, 30*time.Second
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		if opts.MaxPollInterval > 0 {
+			maxInterval = opts.MaxPollInterval
+		}
+	}
+
+	request, err := c.GetContext(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Schedule != "" && request.Schedule != ScheduleImmediate {
+		return nil, fmt.Errorf("waiting for command %s: %w (%s)", requestID, ErrCommandScheduled, request.Schedule)
+	}
+
+	for poll := 1; ; poll++ {
+		statuses, err := c.StatusesContext(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range statuses {
+			if status.State == CommandStateScheduled {
+				return nil, fmt.Errorf("waiting for command %s: %w", requestID, ErrCommandScheduled)
+			}
+		}
+
+		expected := len(request.Devices)
+		if expected == 0 {
+			// Group and dynamic targets are resolved by the server, so
+			// refresh the device counts it reports for the request
+			if poll > 1 {
+				if request, err = c.GetContext(ctx, requestID); err != nil {
+					return nil, err
+				}
+			}
+			expected = request.targetedDevices()
+			if expected == 0 && len(statuses) == 0 && poll >= emptyStatusPolls {
+				return &CommandResult{RequestID: requestID}, nil
+			}
+		}
+		if len(statuses) > 0 && len(statuses) >= expected && allTerminal(statuses) {
+			return &CommandResult{RequestID: requestID, Statuses: statuses}, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("waiting for command %s: %w", requestID, ctx.Err())
+		case <-timer.C:
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+// targetedDevices returns the number of devices counted in the request's status totals
+func (r *CommandResponse) targetedDevices() int {
+	total := 0
+	for _, count := range r.Status {
+		total += count.Total
+	}
+	return total
+}
+
+// allTerminal reports whether all statuses are in a terminal state
+func allTerminal(statuses []CommandStatus) bool {
+	for _, status := range statuses {
+		if !status.State.IsTerminal() {
+			return false
+		}
+	}
+	return true
+}
+
+// commandEndpoint returns the command requests collection, or a single request if requestID is set
+func (c *Commands) commandEndpoint(requestID string) string {
+	endpoint := fmt.Sprintf("/api/v0/enterprise/%s/command/", c.Request.EnterpriseID)
+	if requestID != "" {
+		endpoint += requestID + "/"
+	}
+	return endpoint
+}
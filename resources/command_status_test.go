@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// commandServer serves request as the command request and answers status
+// polls with polls in order, repeating the last one
+func commandServer(t *testing.T, request map[string]interface{}, polls ...[]CommandStatus) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var statusCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/status/") {
+			json.NewEncoder(w).Encode(request)
+			return
+		}
+		n := int(statusCalls.Add(1))
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": polls[min(n, len(polls))-1]})
+	}))
+	t.Cleanup(server.Close)
+	return server, &statusCalls
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	queued := []CommandStatus{{Device: "d1", State: CommandStateQueued}, {Device: "d2", State: CommandStateSuccess}}
+	partial := []CommandStatus{{Device: "d2", State: CommandStateSuccess}}
+	done := []CommandStatus{{Device: "d1", State: CommandStateFailure}, {Device: "d2", State: CommandStateSuccess}}
+	scheduled := []CommandStatus{{Device: "d1", State: CommandStateScheduled}}
+
+	devices := map[string]interface{}{"id": "r1", "schedule": ScheduleImmediate, "devices": []string{"d1", "d2"}}
+	group := map[string]interface{}{"id": "r1", "groups": []string{"g"}}
+	groupOfTwo := map[string]interface{}{"id": "r1", "groups": []string{"g"}, "status": []CommandStateCount{
+		{State: CommandStateQueued, Total: 1},
+		{State: CommandStateSuccess, Total: 1},
+	}}
+	window := map[string]interface{}{"id": "r1", "schedule": ScheduleWindow, "devices": []string{"d1"}}
+
+	tests := []struct {
+		name          string
+		request       map[string]interface{}
+		polls         [][]CommandStatus
+		wantSucceeded []string
+		wantFailed    []string
+		wantPolls     int32
+		wantErr       error
+	}{
+		{"completes", devices, [][]CommandStatus{queued, done}, []string{"d2"}, []string{"d1"}, 2, nil},
+		{"waits for devices without a status", devices, [][]CommandStatus{partial, partial, done}, []string{"d2"}, []string{"d1"}, 3, nil},
+		{"waits for lagging statuses", devices, [][]CommandStatus{nil, nil, nil, done}, []string{"d2"}, []string{"d1"}, 4, nil},
+		{"group resolves to no devices", group, [][]CommandStatus{nil}, nil, nil, emptyStatusPolls, nil},
+		{"group statuses appear on the second poll", group, [][]CommandStatus{nil, done}, []string{"d2"}, []string{"d1"}, 2, nil},
+		{"group waits for counted devices", groupOfTwo, [][]CommandStatus{nil, nil, partial, done}, []string{"d2"}, []string{"d1"}, 4, nil},
+		{"window schedule", window, [][]CommandStatus{queued}, nil, nil, 0, ErrCommandScheduled},
+		{"scheduled status", devices, [][]CommandStatus{scheduled}, nil, nil, 1, ErrCommandScheduled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, statusCalls := commandServer(t, tt.request, tt.polls...)
+			commands := &Commands{Request: testRequest(server.URL)}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := commands.WaitForCompletion(ctx, "r1", &WaitOptions{PollInterval: time.Millisecond})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WaitForCompletion() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := statusCalls.Load(); got != tt.wantPolls {
+				t.Errorf("polled %d times, want %d", got, tt.wantPolls)
+			}
+			if err != nil {
+				return
+			}
+			if !slices.Equal(result.Succeeded(), tt.wantSucceeded) || !slices.Equal(result.Failed(), tt.wantFailed) {
+				t.Errorf("succeeded = %v, failed = %v, want %v, %v", result.Succeeded(), result.Failed(), tt.wantSucceeded, tt.wantFailed)
+			}
+		})
+	}
+}
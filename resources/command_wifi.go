@@ -0,0 +1,224 @@
+package resources
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// WifiSecurityType is the security protocol of a Wi-Fi network
+type WifiSecurityType string
+
+const (
+	WifiSecurityNone WifiSecurityType = "NONE"
+	WifiSecurityWEP  WifiSecurityType = "WEP"
+	WifiSecurityWPA  WifiSecurityType = "WPA"
+	WifiSecurityWPA2 WifiSecurityType = "WPA2"
+	WifiSecurityWPA3 WifiSecurityType = "WPA3"
+	WifiSecurityEAP  WifiSecurityType = "EAP"
+)
+
+// EAPMethod is the outer authentication method of an enterprise network
+type EAPMethod string
+
+const (
+	EAPMethodPEAP EAPMethod = "PEAP"
+	EAPMethodTLS  EAPMethod = "TLS"
+	EAPMethodTTLS EAPMethod = "TTLS"
+	EAPMethodPWD  EAPMethod = "PWD"
+)
+
+// EAPPhase2 is the inner authentication method used by PEAP and TTLS
+type EAPPhase2 string
+
+const (
+	EAPPhase2None     EAPPhase2 = "NONE"
+	EAPPhase2MSCHAPv2 EAPPhase2 = "MSCHAPV2"
+	EAPPhase2GTC      EAPPhase2 = "GTC"
+	EAPPhase2PAP      EAPPhase2 = "PAP"
+)
+
+// WifiAccessPoint describes a Wi-Fi network to save on devices
+type WifiAccessPoint struct {
+	SSID     string           `json:"wifi_ssid"`
+	Security WifiSecurityType `json:"wifi_security_type"`
+	Password string           `json:"wifi_password,omitempty"`
+	Hidden   bool             `json:"hidden_ssid,omitempty"`
+	EAP      *EAPConfig       `json:"eap_config,omitempty"`
+}
+
+// EAPConfig holds the enterprise settings of an EAP network
+type EAPConfig struct {
+	Method            EAPMethod `json:"eap_method"`
+	Phase2            EAPPhase2 `json:"phase2_method,omitempty"`
+	Identity          string    `json:"identity"`
+	AnonymousIdentity string    `json:"anonymous_identity,omitempty"`
+	Password          string    `json:"password,omitempty"`
+	CACertificate     string    `json:"ca_certificate,omitempty"` // PEM encoded
+	Domain            string    `json:"domain,omitempty"`
+
+	// Client credential presented by EAP-TLS, both PEM encoded
+	ClientCertificate string `json:"client_certificate,omitempty"`
+	ClientKey         string `json:"client_private_key,omitempty"`
+}
+
+// Validate checks the access point before it is sent to devices
+func (ap WifiAccessPoint) Validate() error {
+	var errs []error
+	if len(ap.SSID) == 0 || len(ap.SSID) > 32 {
+		errs = append(errs, errors.New("SSID must be between 1 and 32 bytes"))
+	}
+
+	switch ap.Security {
+	case WifiSecurityNone:
+		if ap.Password != "" {
+			errs = append(errs, errors.New("open networks must not have a password"))
+		}
+	case WifiSecurityWEP:
+		if !validWEPKey(ap.Password) {
+			errs = append(errs, errors.New("WEP key must be 5 or 13 characters, or 10 or 26 hex digits"))
+		}
+	case WifiSecurityWPA, WifiSecurityWPA2, WifiSecurityWPA3:
+		if !validWPAPassphrase(ap.Password) {
+			errs = append(errs, errors.New("WPA passphrase must be 8-63 characters or 64 hex digits"))
+		}
+	case WifiSecurityEAP:
+		if ap.EAP == nil {
+			errs = append(errs, errors.New("EAP networks require an EAP config"))
+		} else if err := ap.EAP.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown security type %q", ap.Security))
+	}
+	if ap.Security != WifiSecurityEAP && ap.EAP != nil {
+		errs = append(errs, fmt.Errorf("EAP config is not allowed for %s networks", ap.Security))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid access point %q: %w", ap.SSID, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (e *EAPConfig) validate() error {
+	switch e.Method {
+	case EAPMethodPEAP, EAPMethodTTLS:
+		if e.Password == "" {
+			return fmt.Errorf("%s requires a password", e.Method)
+		}
+	case EAPMethodPWD:
+		if e.Password == "" {
+			return errors.New("PWD requires a password")
+		}
+		if e.Phase2 != "" && e.Phase2 != EAPPhase2None {
+			return errors.New("PWD does not support a phase 2 method")
+		}
+	case EAPMethodTLS:
+		if e.Phase2 != "" && e.Phase2 != EAPPhase2None {
+			return errors.New("TLS does not support a phase 2 method")
+		}
+		if e.ClientCertificate == "" || e.ClientKey == "" {
+			return errors.New("TLS requires a client certificate and private key")
+		}
+		if _, err := tls.X509KeyPair([]byte(e.ClientCertificate), []byte(e.ClientKey)); err != nil {
+			return fmt.Errorf("invalid TLS client credential: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown EAP method %q", e.Method)
+	}
+	if e.Method != EAPMethodTLS && (e.ClientCertificate != "" || e.ClientKey != "") {
+		return fmt.Errorf("%s does not use a client certificate", e.Method)
+	}
+
+	switch e.Phase2 {
+	case "", EAPPhase2None, EAPPhase2MSCHAPv2, EAPPhase2GTC, EAPPhase2PAP:
+	default:
+		return fmt.Errorf("unknown EAP phase 2 method %q", e.Phase2)
+	}
+	if e.Identity == "" {
+		return errors.New("EAP identity is required")
+	}
+	return nil
+}
+
+func validWEPKey(key string) bool {
+	switch len(key) {
+	case 5, 13:
+		return true
+	case 10, 26:
+		return isHex(key)
+	}
+	return false
+}
+
+func validWPAPassphrase(passphrase string) bool {
+	if len(passphrase) == 64 {
+		return isHex(passphrase)
+	}
+	return len(passphrase) >= 8 && len(passphrase) <= 63
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// AddWifiAP saves Wi-Fi access points on the target devices
+func (c *Commands) AddWifiAP(target Target, accessPoints ...WifiAccessPoint) (*CommandResponse, error) {
+	return c.AddWifiAPContext(context.Background(), target, accessPoints...)
+}
+
+// AddWifiAPContext is like AddWifiAP but carries ctx for cancellation and deadlines
+func (c *Commands) AddWifiAPContext(ctx context.Context, target Target, accessPoints ...WifiAccessPoint) (*CommandResponse, error) {
+	if len(accessPoints) == 0 {
+		return nil, errors.New("at least one access point is required")
+	}
+	for _, ap := range accessPoints {
+		if err := ap.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandAddWifiAP, map[string]interface{}{
+		"wifi_access_points": accessPoints,
+	}))
+}
+
+// RemoveWifiAP removes saved Wi-Fi access points from the target devices
+func (c *Commands) RemoveWifiAP(target Target, ssids ...string) (*CommandResponse, error) {
+	return c.RemoveWifiAPContext(context.Background(), target, ssids...)
+}
+
+// RemoveWifiAPContext is like RemoveWifiAP but carries ctx for cancellation and deadlines
+func (c *Commands) RemoveWifiAPContext(ctx context.Context, target Target, ssids ...string) (*CommandResponse, error) {
+	if len(ssids) == 0 {
+		return nil, errors.New("at least one SSID is required")
+	}
+
+	accessPoints := make([]map[string]interface{}, 0, len(ssids))
+	for _, ssid := range ssids {
+		if len(ssid) == 0 || len(ssid) > 32 {
+			return nil, fmt.Errorf("SSID %q must be between 1 and 32 bytes", ssid)
+		}
+		accessPoints = append(accessPoints, map[string]interface{}{"wifi_ssid": ssid})
+	}
+
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandRemoveWifiAP, map[string]interface{}{
+		"wifi_access_points": accessPoints,
+	}))
+}
+
+// UseOnlySavedWifiAP restricts the target devices to saved Wi-Fi networks, or lifts the restriction
+func (c *Commands) UseOnlySavedWifiAP(target Target, enabled bool) (*CommandResponse, error) {
+	return c.UseOnlySavedWifiAPContext(context.Background(), target, enabled)
+}
+
+// UseOnlySavedWifiAPContext is like UseOnlySavedWifiAP but carries ctx for cancellation and deadlines
+func (c *Commands) UseOnlySavedWifiAPContext(ctx context.Context, target Target, enabled bool) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandUseOnlySavedWifiAP, map[string]interface{}{
+		"use_only_saved_wifi_ap": enabled,
+	}))
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clientCredential returns a self-signed PEM certificate and its private key
+func clientCredential(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "device"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestEAPTLSRequiresClientCredential(t *testing.T) {
+	cert, key := clientCredential(t)
+	_, otherKey := clientCredential(t)
+
+	tests := []struct {
+		name    string
+		eap     EAPConfig
+		wantErr string
+	}{
+		{"valid", EAPConfig{Method: EAPMethodTLS, Identity: "i", ClientCertificate: cert, ClientKey: key}, ""},
+		{"identity only", EAPConfig{Method: EAPMethodTLS, Identity: "i"}, "requires a client certificate"},
+		{"missing key", EAPConfig{Method: EAPMethodTLS, Identity: "i", ClientCertificate: cert}, "requires a client certificate"},
+		{"mismatched key", EAPConfig{Method: EAPMethodTLS, Identity: "i", ClientCertificate: cert, ClientKey: otherKey}, "invalid TLS client credential"},
+		{"certificate on PEAP", EAPConfig{Method: EAPMethodPEAP, Identity: "i", Password: "p", ClientCertificate: cert}, "does not use a client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap := WifiAccessPoint{SSID: "corp", Security: WifiSecurityEAP, EAP: &tt.eap}
+			err := ap.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package resources
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"maps"
+	"net/http"
 	"time"
 
 	"github.com/Hasaber8/esper-go-sdk/requests"
@@ -10,6 +13,20 @@ import (
 // Commands handles command-related API operations
 type Commands struct {
 	Request *requests.Request
+
+	schedule *Schedule // Applied to immediate requests, see WithSchedule
+}
+
+// WithSchedule returns a copy of c that sends commands on schedule instead of
+// immediately, e.g. c.WithSchedule(s).InstallApp(target, appVersionID). It
+// covers the helpers, SendCommand and SendCommandRequest; a request that
+// already has a WINDOW or RECURRING schedule, such as one sent through
+// SendScheduledCommand, keeps its own. The schedule is validated when a
+// command is sent.
+func (c *Commands) WithSchedule(schedule Schedule) *Commands {
+	scheduled := *c
+	scheduled.schedule = &schedule
+	return &scheduled
 }
 
 // CommandType represents the type of command target
@@ -76,205 +93,250 @@ const (
 
 // SendCommand sends a command with the given body to the commands endpoint
 // This maintains backward compatibility with your existing code
-func (c *Commands) SendCommand(body map[string]interface{}) (*requests.APIResponse, error) {
-	endpoint := fmt.Sprintf("/api/v0/enterprise/%s/command/", c.Request.EnterpriseID)
-	return c.Request.Post(endpoint, body)
+func (c *Commands) SendCommand(body map[string]interface{}) (*CommandResponse, error) {
+	return c.SendCommandContext(context.Background(), body)
 }
 
-// Convenience methods for common operations
+// SendCommandContext is like SendCommand but carries ctx for cancellation and deadlines
+func (c *Commands) SendCommandContext(ctx context.Context, body map[string]interface{}) (*CommandResponse, error) {
+	if c.schedule != nil && isImmediate(body["schedule"]) {
+		if err := c.schedule.Validate(); err != nil {
+			return nil, err
+		}
+		body = maps.Clone(body)
+		body["schedule"] = c.schedule.Type
+		delete(body, "schedule_args")
+		if args := c.schedule.args(); args != nil {
+			body["schedule_args"] = args
+		}
+	}
+	if err := validateCommandBody(body); err != nil {
+		return nil, err
+	}
+	return c.post(ctx, body)
+}
 
-// Reboot reboots the specified devices
-func (c *Commands) Reboot(devices []string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandReboot),
-		"schedule":     string(ScheduleImmediate),
+// SendCommandRequest validates and sends a typed command request
+func (c *Commands) SendCommandRequest(req *CommandRequest) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(context.Background(), req)
+}
+
+// SendCommandRequestContext is like SendCommandRequest but carries ctx for cancellation and deadlines
+func (c *Commands) SendCommandRequestContext(ctx context.Context, req *CommandRequest) (*CommandResponse, error) {
+	if req == nil {
+		return nil, errors.New("command request is nil")
+	}
+	if c.schedule != nil && isImmediate(req.Schedule) {
+		if err := c.schedule.Validate(); err != nil {
+			return nil, err
+		}
+		scheduled := *req
+		c.schedule.apply(&scheduled)
+		req = &scheduled
 	}
-	return c.SendCommand(body)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.post(ctx, req)
 }
 
-// Lock locks the specified devices
-func (c *Commands) Lock(devices []string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandLock),
-		"schedule":     string(ScheduleImmediate),
+// isImmediate reports whether the schedule field of a request, typed or raw,
+// asks for immediate execution
+func isImmediate(schedule interface{}) bool {
+	switch v := schedule.(type) {
+	case nil:
+		return true
+	case string:
+		return v == "" || v == string(ScheduleImmediate)
+	case ScheduleType:
+		return v == "" || v == ScheduleImmediate
 	}
-	return c.SendCommand(body)
+	return false
 }
 
-// Wipe wipes the specified devices
-func (c *Commands) Wipe(devices []string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandWipe),
-		"schedule":     string(ScheduleImmediate),
+// post submits a command body and decodes the created command request
+func (c *Commands) post(ctx context.Context, body interface{}) (*CommandResponse, error) {
+	var resp CommandResponse
+	if err := c.Request.Do(ctx, http.MethodPost, c.commandEndpoint(""), nil, body, &resp); err != nil {
+		return nil, err
 	}
-	return c.SendCommand(body)
+	return &resp, nil
+}
+
+// Convenience methods for common operations.
+// Each helper accepts a Target, so the same command can be sent to devices,
+// groups or a dynamic set, e.g. c.InstallApp(GroupTarget(groupID), appVersionID).
+//
+// The helpers used to take a devices []string. Go has no overloading, so a
+// helper cannot accept both; callers migrate by wrapping the slice, e.g.
+// c.Reboot(DeviceTarget(devices...)). The group-only helpers had names of
+// their own and are kept below as deprecated wrappers.
+
+// Reboot reboots the specified devices
+func (c *Commands) Reboot(target Target) (*CommandResponse, error) {
+	return c.RebootContext(context.Background(), target)
+}
+
+// RebootContext is like Reboot but carries ctx for cancellation and deadlines
+func (c *Commands) RebootContext(ctx context.Context, target Target) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandReboot, nil))
+}
+
+// Lock locks the specified devices
+func (c *Commands) Lock(target Target) (*CommandResponse, error) {
+	return c.LockContext(context.Background(), target)
+}
+
+// LockContext is like Lock but carries ctx for cancellation and deadlines
+func (c *Commands) LockContext(ctx context.Context, target Target) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandLock, nil))
+}
+
+// Wipe wipes the specified devices
+func (c *Commands) Wipe(target Target) (*CommandResponse, error) {
+	return c.WipeContext(context.Background(), target)
+}
+
+// WipeContext is like Wipe but carries ctx for cancellation and deadlines
+func (c *Commands) WipeContext(ctx context.Context, target Target) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandWipe, nil))
 }
 
 // InstallApp installs an app on devices
-func (c *Commands) InstallApp(devices []string, appVersionID string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandInstall),
-		"command_args": map[string]interface{}{
-			"app_version": appVersionID,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) InstallApp(target Target, appVersionID string) (*CommandResponse, error) {
+	return c.InstallAppContext(context.Background(), target, appVersionID)
+}
+
+// InstallAppContext is like InstallApp but carries ctx for cancellation and deadlines
+func (c *Commands) InstallAppContext(ctx context.Context, target Target, appVersionID string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandInstall, map[string]interface{}{
+		"app_version": appVersionID,
+	}))
 }
 
 // UninstallApp uninstalls an app from devices
-func (c *Commands) UninstallApp(devices []string, packageName string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandUninstall),
-		"command_args": map[string]interface{}{
-			"package_name": packageName,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) UninstallApp(target Target, packageName string) (*CommandResponse, error) {
+	return c.UninstallAppContext(context.Background(), target, packageName)
+}
+
+// UninstallAppContext is like UninstallApp but carries ctx for cancellation and deadlines
+func (c *Commands) UninstallAppContext(ctx context.Context, target Target, packageName string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandUninstall, map[string]interface{}{
+		"package_name": packageName,
+	}))
 }
 
 // ClearAppData clears app data on devices
-func (c *Commands) ClearAppData(devices []string, packageName string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandClearAppData),
-		"command_args": map[string]interface{}{
-			"package_name": packageName,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) ClearAppData(target Target, packageName string) (*CommandResponse, error) {
+	return c.ClearAppDataContext(context.Background(), target, packageName)
+}
+
+// ClearAppDataContext is like ClearAppData but carries ctx for cancellation and deadlines
+func (c *Commands) ClearAppDataContext(ctx context.Context, target Target, packageName string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandClearAppData, map[string]interface{}{
+		"package_name": packageName,
+	}))
 }
 
 // SetKioskApp sets the kiosk app on devices
-func (c *Commands) SetKioskApp(devices []string, packageName string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetKioskApp),
-		"command_args": map[string]interface{}{
-			"package_name": packageName,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) SetKioskApp(target Target, packageName string) (*CommandResponse, error) {
+	return c.SetKioskAppContext(context.Background(), target, packageName)
+}
+
+// SetKioskAppContext is like SetKioskApp but carries ctx for cancellation and deadlines
+func (c *Commands) SetKioskAppContext(ctx context.Context, target Target, packageName string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetKioskApp, map[string]interface{}{
+		"package_name": packageName,
+	}))
 }
 
 // SetAppState sets the state of an app (SHOW/HIDE/DISABLE)
-func (c *Commands) SetAppState(devices []string, packageName string, state string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetAppState),
-		"command_args": map[string]interface{}{
-			"package_name": packageName,
-			"app_state":    state,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) SetAppState(target Target, packageName string, state AppState) (*CommandResponse, error) {
+	return c.SetAppStateContext(context.Background(), target, packageName, state)
+}
+
+// SetAppStateContext is like SetAppState but carries ctx for cancellation and deadlines
+func (c *Commands) SetAppStateContext(ctx context.Context, target Target, packageName string, state AppState) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetAppState, map[string]interface{}{
+		"package_name": packageName,
+		"app_state":    string(state),
+	}))
 }
 
 // SetBrightness sets the brightness on devices (1-100)
-func (c *Commands) SetBrightness(devices []string, brightness int) (*requests.APIResponse, error) {
-	if brightness < 1 || brightness > 100 {
-		return nil, fmt.Errorf("brightness must be between 1 and 100")
-	}
+func (c *Commands) SetBrightness(target Target, brightness int) (*CommandResponse, error) {
+	return c.SetBrightnessContext(context.Background(), target, brightness)
+}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetBrightnessScale),
-		"command_args": map[string]interface{}{
-			"brightness_value": brightness,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+// SetBrightnessContext is like SetBrightness but carries ctx for cancellation and deadlines
+func (c *Commands) SetBrightnessContext(ctx context.Context, target Target, brightness int) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetBrightnessScale, map[string]interface{}{
+		"brightness_value": brightness,
+	}))
 }
 
-// SetVolume sets the volume on devices
-// stream: 0=Ring, 1=Notification, 2=Alarm, 3=Music
+// SetVolume sets the volume of an audio stream on devices
 // volume: 0-100
-func (c *Commands) SetVolume(devices []string, stream, volume int) (*requests.APIResponse, error) {
-	if stream < 0 || stream > 3 {
-		return nil, fmt.Errorf("stream must be 0-3")
-	}
-	if volume < 0 || volume > 100 {
-		return nil, fmt.Errorf("volume must be 0-100")
-	}
+func (c *Commands) SetVolume(target Target, stream AudioStream, volume int) (*CommandResponse, error) {
+	return c.SetVolumeContext(context.Background(), target, stream, volume)
+}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetStreamVolume),
-		"command_args": map[string]interface{}{
-			"stream":       stream,
-			"volume_level": volume,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+// SetVolumeContext is like SetVolume but carries ctx for cancellation and deadlines
+func (c *Commands) SetVolumeContext(ctx context.Context, target Target, stream AudioStream, volume int) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetStreamVolume, map[string]interface{}{
+		"stream":       int(stream),
+		"volume_level": volume,
+	}))
 }
 
 // SetWifiState enables or disables WiFi on devices
-func (c *Commands) SetWifiState(devices []string, enabled bool) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetWifiState),
-		"command_args": map[string]interface{}{
-			"wifi_state": enabled,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) SetWifiState(target Target, enabled bool) (*CommandResponse, error) {
+	return c.SetWifiStateContext(context.Background(), target, enabled)
+}
+
+// SetWifiStateContext is like SetWifiState but carries ctx for cancellation and deadlines
+func (c *Commands) SetWifiStateContext(ctx context.Context, target Target, enabled bool) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetWifiState, map[string]interface{}{
+		"wifi_state": enabled,
+	}))
 }
 
 // SetBluetoothState enables or disables Bluetooth on devices
-func (c *Commands) SetBluetoothState(devices []string, enabled bool) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetBluetoothState),
-		"command_args": map[string]interface{}{
-			"bluetooth_state": enabled,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) SetBluetoothState(target Target, enabled bool) (*CommandResponse, error) {
+	return c.SetBluetoothStateContext(context.Background(), target, enabled)
 }
 
-// UpdateDeviceConfig updates device configuration
-func (c *Commands) UpdateDeviceConfig(devices []string, config map[string]interface{}) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandUpdateDeviceConfig),
-		"command_args": config,
-		"schedule":     string(ScheduleImmediate),
-	}
+// SetBluetoothStateContext is like SetBluetoothState but carries ctx for cancellation and deadlines
+func (c *Commands) SetBluetoothStateContext(ctx context.Context, target Target, enabled bool) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetBluetoothState, map[string]interface{}{
+		"bluetooth_state": enabled,
+	}))
+}
+
+// UpdateDeviceConfig updates device configuration from a raw command_args map.
+// Prefer UpdateCustomSettings for custom_settings_config payloads.
+func (c *Commands) UpdateDeviceConfig(target Target, config map[string]interface{}) (*CommandResponse, error) {
+	return c.UpdateDeviceConfigContext(context.Background(), target, config)
+}
+
+// UpdateDeviceConfigContext is like UpdateDeviceConfig but carries ctx for cancellation and deadlines
+func (c *Commands) UpdateDeviceConfigContext(ctx context.Context, target Target, config map[string]interface{}) (*CommandResponse, error) {
+	req := targetCommand(target, CommandUpdateDeviceConfig, config)
 	// Special handling for device_type if not in config
 	if _, ok := config["device_type"]; !ok {
-		body["device_type"] = "all"
+		req.DeviceType = DeviceTypeAll
 	}
-	return c.SendCommand(body)
+	return c.SendCommandRequestContext(ctx, req)
 }
 
 // NotifyDevice sends a notification to devices
-func (c *Commands) NotifyDevice(devices []string, title, message string, url ...string) (*requests.APIResponse, error) {
+func (c *Commands) NotifyDevice(target Target, title, message string, url ...string) (*CommandResponse, error) {
+	return c.NotifyDeviceContext(context.Background(), target, title, message, url...)
+}
+
+// NotifyDeviceContext is like NotifyDevice but carries ctx for cancellation and deadlines
+func (c *Commands) NotifyDeviceContext(ctx context.Context, target Target, title, message string, url ...string) (*CommandResponse, error) {
 	args := map[string]interface{}{
 		"title":   title,
 		"message": message,
@@ -283,274 +345,265 @@ func (c *Commands) NotifyDevice(devices []string, title, message string, url ...
 		args["url"] = url[0]
 	}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandNotifyDevice),
-		"command_args": args,
-		"schedule":     string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandNotifyDevice, args))
 }
 
 // CaptureScreenshot captures a screenshot on devices
-func (c *Commands) CaptureScreenshot(devices []string, tag ...string) (*requests.APIResponse, error) {
+func (c *Commands) CaptureScreenshot(target Target, tag ...string) (*CommandResponse, error) {
+	return c.CaptureScreenshotContext(context.Background(), target, tag...)
+}
+
+// CaptureScreenshotContext is like CaptureScreenshot but carries ctx for cancellation and deadlines
+func (c *Commands) CaptureScreenshotContext(ctx context.Context, target Target, tag ...string) (*CommandResponse, error) {
 	args := map[string]interface{}{}
 	if len(tag) > 0 {
 		args["tag"] = tag[0]
 	}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandCaptureScreenshot),
-		"command_args": args,
-		"schedule":     string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandCaptureScreenshot, args))
 }
 
 // SetDeviceLanguage sets the language on devices
-func (c *Commands) SetDeviceLanguage(devices []string, locale string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetDeviceLanguage),
-		"command_args": map[string]interface{}{
-			"locale": locale,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) SetDeviceLanguage(target Target, locale string) (*CommandResponse, error) {
+	return c.SetDeviceLanguageContext(context.Background(), target, locale)
+}
+
+// SetDeviceLanguageContext is like SetDeviceLanguage but carries ctx for cancellation and deadlines
+func (c *Commands) SetDeviceLanguageContext(ctx context.Context, target Target, locale string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetDeviceLanguage, map[string]interface{}{
+		"locale": locale,
+	}))
 }
 
 // BeepDevice makes devices beep for a specified duration
-func (c *Commands) BeepDevice(devices []string, duration string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandBeepDevice),
-		"command_args": map[string]interface{}{
-			"duration": duration,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) BeepDevice(target Target, duration string) (*CommandResponse, error) {
+	return c.BeepDeviceContext(context.Background(), target, duration)
+}
+
+// BeepDeviceContext is like BeepDevice but carries ctx for cancellation and deadlines
+func (c *Commands) BeepDeviceContext(ctx context.Context, target Target, duration string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandBeepDevice, map[string]interface{}{
+		"duration": duration,
+	}))
 }
 
 // ResetPassword resets the lockscreen password
-func (c *Commands) ResetPassword(devices []string, newPassword string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandResetLockscreenPassword),
-		"command_args": map[string]interface{}{
-			"new_lockscreen_password": newPassword,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) ResetPassword(target Target, newPassword string) (*CommandResponse, error) {
+	return c.ResetPasswordContext(context.Background(), target, newPassword)
+}
+
+// ResetPasswordContext is like ResetPassword but carries ctx for cancellation and deadlines
+func (c *Commands) ResetPasswordContext(ctx context.Context, target Target, newPassword string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandResetLockscreenPassword, map[string]interface{}{
+		"new_lockscreen_password": newPassword,
+	}))
 }
 
 // UpdateBlueprint pushes or reapplies the current Blueprint to devices
-func (c *Commands) UpdateBlueprint(devices []string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandUpdateBlueprint),
-		"schedule":     string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) UpdateBlueprint(target Target) (*CommandResponse, error) {
+	return c.UpdateBlueprintContext(context.Background(), target)
 }
 
-// SetGPSState sets GPS state
-// state: 0=High Accuracy, 1=Sensors Only, 2=Battery Saving, 3=Off, 4=On
-func (c *Commands) SetGPSState(devices []string, state int) (*requests.APIResponse, error) {
-	if state < 0 || state > 4 {
-		return nil, fmt.Errorf("gps_state must be between 0 and 4")
-	}
+// UpdateBlueprintContext is like UpdateBlueprint but carries ctx for cancellation and deadlines
+func (c *Commands) UpdateBlueprintContext(ctx context.Context, target Target) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandUpdateBlueprint, nil))
+}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetGPSState),
-		"command_args": map[string]interface{}{
-			"gps_state": state,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+// SetGPSState sets the GPS mode
+func (c *Commands) SetGPSState(target Target, mode GPSMode) (*CommandResponse, error) {
+	return c.SetGPSStateContext(context.Background(), target, mode)
+}
+
+// SetGPSStateContext is like SetGPSState but carries ctx for cancellation and deadlines
+func (c *Commands) SetGPSStateContext(ctx context.Context, target Target, mode GPSMode) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetGPSState, map[string]interface{}{
+		"gps_state": int(mode),
+	}))
 }
 
 // SetRotationState sets screen orientation
-// state: 0=Auto, 1=Portrait Only, 2=Landscape Only
-func (c *Commands) SetRotationState(devices []string, state int) (*requests.APIResponse, error) {
-	if state < 0 || state > 2 {
-		return nil, fmt.Errorf("rotate_state must be 0, 1, or 2")
-	}
+func (c *Commands) SetRotationState(target Target, mode RotationMode) (*CommandResponse, error) {
+	return c.SetRotationStateContext(context.Background(), target, mode)
+}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetRotationState),
-		"command_args": map[string]interface{}{
-			"rotate_state": state,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+// SetRotationStateContext is like SetRotationState but carries ctx for cancellation and deadlines
+func (c *Commands) SetRotationStateContext(ctx context.Context, target Target, mode RotationMode) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetRotationState, map[string]interface{}{
+		"rotate_state": int(mode),
+	}))
 }
 
 // SetScreenOffTimeout sets screen off timeout
 // timeout: -1 or between 5000 and 1800000 milliseconds
-func (c *Commands) SetScreenOffTimeout(devices []string, timeout int) (*requests.APIResponse, error) {
-	if timeout != -1 && (timeout < 5000 || timeout > 1800000) {
-		return nil, fmt.Errorf("screen_off_timeout must be -1 or between 5000 and 1800000")
-	}
+func (c *Commands) SetScreenOffTimeout(target Target, timeout int) (*CommandResponse, error) {
+	return c.SetScreenOffTimeoutContext(context.Background(), target, timeout)
+}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetScreenOffTimeout),
-		"command_args": map[string]interface{}{
-			"screen_off_timeout": timeout,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+// SetScreenOffTimeoutContext is like SetScreenOffTimeout but carries ctx for cancellation and deadlines
+func (c *Commands) SetScreenOffTimeoutContext(ctx context.Context, target Target, timeout int) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetScreenOffTimeout, map[string]interface{}{
+		"screen_off_timeout": timeout,
+	}))
 }
 
 // SetTimezone sets the timezone for devices
-func (c *Commands) SetTimezone(devices []string, timezone string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetTimezone),
-		"command_args": map[string]interface{}{
-			"timezone_string": timezone,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) SetTimezone(target Target, timezone string) (*CommandResponse, error) {
+	return c.SetTimezoneContext(context.Background(), target, timezone)
+}
+
+// SetTimezoneContext is like SetTimezone but carries ctx for cancellation and deadlines
+func (c *Commands) SetTimezoneContext(ctx context.Context, target Target, timezone string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetTimezone, map[string]interface{}{
+		"timezone_string": timezone,
+	}))
 }
 
 // ApplyPolicy applies a policy to devices
-func (c *Commands) ApplyPolicy(devices []string, policyURL string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetNewPolicy),
-		"command_args": map[string]interface{}{
-			"policy_url": policyURL,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+func (c *Commands) ApplyPolicy(target Target, policyURL string) (*CommandResponse, error) {
+	return c.ApplyPolicyContext(context.Background(), target, policyURL)
+}
+
+// ApplyPolicyContext is like ApplyPolicy but carries ctx for cancellation and deadlines
+func (c *Commands) ApplyPolicyContext(ctx context.Context, target Target, policyURL string) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetNewPolicy, map[string]interface{}{
+		"policy_url": policyURL,
+	}))
 }
 
 // SetDeviceLockdown sets lockdown state for devices
-func (c *Commands) SetDeviceLockdown(devices []string, locked bool, message string) (*requests.APIResponse, error) {
+func (c *Commands) SetDeviceLockdown(target Target, locked bool, message string) (*CommandResponse, error) {
+	return c.SetDeviceLockdownContext(context.Background(), target, locked, message)
+}
+
+// SetDeviceLockdownContext is like SetDeviceLockdown but carries ctx for cancellation and deadlines
+func (c *Commands) SetDeviceLockdownContext(ctx context.Context, target Target, locked bool, message string) (*CommandResponse, error) {
 	state := "UNLOCKED"
 	if locked {
 		state = "LOCKED"
 	}
 
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandSetDeviceLockdownState),
-		"command_args": map[string]interface{}{
-			"state":   state,
-			"message": message,
-		},
-		"schedule": string(ScheduleImmediate),
-	}
-	return c.SendCommand(body)
+	return c.SendCommandRequestContext(ctx, targetCommand(target, CommandSetDeviceLockdownState, map[string]interface{}{
+		"state":   state,
+		"message": message,
+	}))
+}
+
+// SendTargetCommand sends command with args to any target: devices, groups or a dynamic set
+func (c *Commands) SendTargetCommand(target Target, command Command, args map[string]interface{}) (*CommandResponse, error) {
+	return c.SendTargetCommandContext(context.Background(), target, command, args)
+}
+
+// SendTargetCommandContext is like SendTargetCommand but carries ctx for cancellation and deadlines
+func (c *Commands) SendTargetCommandContext(ctx context.Context, target Target, command Command, args map[string]interface{}) (*CommandResponse, error) {
+	return c.SendCommandRequestContext(ctx, targetCommand(target, command, args))
 }
 
 // Group command methods
 
 // SendGroupCommand sends a command to device groups
-func (c *Commands) SendGroupCommand(groups []string, command Command, args map[string]interface{}) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeGroup),
-		"groups":       groups,
-		"command":      string(command),
-		"schedule":     string(ScheduleImmediate),
-	}
-	if args != nil {
-		body["command_args"] = args
-	}
-	return c.SendCommand(body)
+func (c *Commands) SendGroupCommand(groups []string, command Command, args map[string]interface{}) (*CommandResponse, error) {
+	return c.SendGroupCommandContext(context.Background(), groups, command, args)
+}
+
+// SendGroupCommandContext is like SendGroupCommand but carries ctx for cancellation and deadlines
+func (c *Commands) SendGroupCommandContext(ctx context.Context, groups []string, command Command, args map[string]interface{}) (*CommandResponse, error) {
+	return c.SendTargetCommandContext(ctx, GroupTarget(groups...), command, args)
 }
 
 // RebootGroups reboots all devices in specified groups
-func (c *Commands) RebootGroups(groups []string) (*requests.APIResponse, error) {
-	return c.SendGroupCommand(groups, CommandReboot, nil)
+//
+// Deprecated: use Reboot with GroupTarget.
+func (c *Commands) RebootGroups(groups []string) (*CommandResponse, error) {
+	return c.RebootGroupsContext(context.Background(), groups)
+}
+
+// RebootGroupsContext is like RebootGroups but carries ctx for cancellation and deadlines
+func (c *Commands) RebootGroupsContext(ctx context.Context, groups []string) (*CommandResponse, error) {
+	return c.RebootContext(ctx, GroupTarget(groups...))
 }
 
 // LockGroups locks all devices in specified groups
-func (c *Commands) LockGroups(groups []string) (*requests.APIResponse, error) {
-	return c.SendGroupCommand(groups, CommandLock, nil)
+//
+// Deprecated: use Lock with GroupTarget.
+func (c *Commands) LockGroups(groups []string) (*CommandResponse, error) {
+	return c.LockGroupsContext(context.Background(), groups)
+}
+
+// LockGroupsContext is like LockGroups but carries ctx for cancellation and deadlines
+func (c *Commands) LockGroupsContext(ctx context.Context, groups []string) (*CommandResponse, error) {
+	return c.LockContext(ctx, GroupTarget(groups...))
 }
 
 // ApplyPolicyToGroups applies a policy to all devices in groups
-func (c *Commands) ApplyPolicyToGroups(groups []string, policyURL string) (*requests.APIResponse, error) {
-	args := map[string]interface{}{
-		"policy_url": policyURL,
-	}
-	return c.SendGroupCommand(groups, CommandSetNewPolicy, args)
+//
+// Deprecated: use ApplyPolicy with GroupTarget.
+func (c *Commands) ApplyPolicyToGroups(groups []string, policyURL string) (*CommandResponse, error) {
+	return c.ApplyPolicyToGroupsContext(context.Background(), groups, policyURL)
+}
+
+// ApplyPolicyToGroupsContext is like ApplyPolicyToGroups but carries ctx for cancellation and deadlines
+func (c *Commands) ApplyPolicyToGroupsContext(ctx context.Context, groups []string, policyURL string) (*CommandResponse, error) {
+	return c.ApplyPolicyContext(ctx, GroupTarget(groups...), policyURL)
 }
 
 // Scheduled command helpers
 
 // SendScheduledCommand sends a command with custom scheduling
-func (c *Commands) SendScheduledCommand(body map[string]interface{}, scheduleType ScheduleType, scheduleArgs map[string]interface{}) (*requests.APIResponse, error) {
+func (c *Commands) SendScheduledCommand(body map[string]interface{}, scheduleType ScheduleType, scheduleArgs map[string]interface{}) (*CommandResponse, error) {
+	return c.SendScheduledCommandContext(context.Background(), body, scheduleType, scheduleArgs)
+}
+
+// SendScheduledCommandContext is like SendScheduledCommand but carries ctx for cancellation and deadlines
+func (c *Commands) SendScheduledCommandContext(ctx context.Context, body map[string]interface{}, scheduleType ScheduleType, scheduleArgs map[string]interface{}) (*CommandResponse, error) {
 	body["schedule"] = string(scheduleType)
 	if scheduleArgs != nil {
 		body["schedule_args"] = scheduleArgs
 	}
-	return c.SendCommand(body)
+	return c.SendCommandContext(ctx, body)
 }
 
-// ScheduleRebootWindow schedules a reboot within a time window
-func (c *Commands) ScheduleRebootWindow(devices []string, startTime, endTime time.Time, windowStart, windowEnd string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandReboot),
-	}
+// ScheduleRebootWindow schedules a reboot within a time window.
+// windowStart and windowEnd are "HH:MM" times on the console clock.
+func (c *Commands) ScheduleRebootWindow(target Target, startTime, endTime time.Time, windowStart, windowEnd string) (*CommandResponse, error) {
+	return c.ScheduleRebootWindowContext(context.Background(), target, startTime, endTime, windowStart, windowEnd)
+}
 
-	scheduleArgs := map[string]interface{}{
-		"start_datetime":    startTime.Format(time.RFC3339),
-		"end_datetime":      endTime.Format(time.RFC3339),
-		"window_start_time": windowStart,
-		"window_end_time":   windowEnd,
-		"time_type":         "console",
+// ScheduleRebootWindowContext is like ScheduleRebootWindow but carries ctx for cancellation and deadlines
+func (c *Commands) ScheduleRebootWindowContext(ctx context.Context, target Target, startTime, endTime time.Time, windowStart, windowEnd string) (*CommandResponse, error) {
+	window, err := parseTimeWindow(windowStart, windowEnd)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.SendScheduledCommand(body, ScheduleWindow, scheduleArgs)
+	schedule := WindowSchedule(startTime, endTime, window, TimeTypeConsole)
+	return c.WithSchedule(schedule).RebootContext(ctx, target)
 }
 
-// ScheduleRecurringNotification schedules recurring notifications
-func (c *Commands) ScheduleRecurringNotification(devices []string, name, title, message string, startTime, endTime time.Time, days []string) (*requests.APIResponse, error) {
-	body := map[string]interface{}{
-		"command_type": string(CommandTypeDevice),
-		"devices":      devices,
-		"command":      string(CommandNotifyDevice),
-		"command_args": map[string]interface{}{
-			"title":   title,
-			"message": message,
-		},
-	}
+// ScheduleRecurringNotification schedules recurring notifications on the given
+// day names, e.g. "monday", on the console clock
+func (c *Commands) ScheduleRecurringNotification(target Target, name, title, message string, startTime, endTime time.Time, days []string) (*CommandResponse, error) {
+	return c.ScheduleRecurringNotificationContext(context.Background(), target, name, title, message, startTime, endTime, days)
+}
 
-	scheduleArgs := map[string]interface{}{
-		"name":           name,
-		"start_datetime": startTime.Format(time.RFC3339),
-		"end_datetime":   endTime.Format(time.RFC3339),
-		"days":           days,
-		"time_type":      "console",
+// ScheduleRecurringNotificationContext is like ScheduleRecurringNotification but carries ctx for cancellation and deadlines
+func (c *Commands) ScheduleRecurringNotificationContext(ctx context.Context, target Target, name, title, message string, startTime, endTime time.Time, days []string) (*CommandResponse, error) {
+	weekdays, err := ParseWeekdaySet(days...)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.SendScheduledCommand(body, ScheduleRecurring, scheduleArgs)
+	schedule := RecurringSchedule(name, startTime, endTime, weekdays, nil, TimeTypeConsole)
+	return c.WithSchedule(schedule).NotifyDeviceContext(ctx, target, title, message)
+}
+
+func parseTimeWindow(start, end string) (TimeWindow, error) {
+	windowStart, err := ParseClockTime(start)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	windowEnd, err := ParseClockTime(end)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	return TimeWindow{Start: windowStart, End: windowEnd}, nil
 }
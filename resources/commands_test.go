@@ -0,0 +1,86 @@
+package resources
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// captureServer records the body of every command posted to it
+func captureServer(t *testing.T) (*httptest.Server, *[]CommandRequest) {
+	t.Helper()
+	sent := new([]CommandRequest)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CommandRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		*sent = append(*sent, req)
+		json.NewEncoder(w).Encode(map[string]string{"id": "r1"})
+	}))
+	t.Cleanup(server.Close)
+	return server, sent
+}
+
+func TestWithScheduleAppliesToEverySendPath(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	window := TimeWindow{Start: ClockTime{Hour: 2}, End: ClockTime{Hour: 4}}
+	schedule := WindowSchedule(start, start.Add(24*time.Hour), window, TimeTypeDevice)
+	rawBody := func() map[string]interface{} {
+		return map[string]interface{}{"command_type": "DEVICE", "devices": []string{"d"}, "command": "REBOOT"}
+	}
+
+	tests := []struct {
+		name         string
+		send         func(c *Commands) error
+		wantSchedule ScheduleType
+	}{
+		{"helper", func(c *Commands) error {
+			_, err := c.Reboot(DeviceTarget("d"))
+			return err
+		}, ScheduleWindow},
+		{"typed request", func(c *Commands) error {
+			_, err := c.SendCommandRequest(targetCommand(DeviceTarget("d"), CommandReboot, nil))
+			return err
+		}, ScheduleWindow},
+		{"raw body", func(c *Commands) error {
+			_, err := c.SendCommand(rawBody())
+			return err
+		}, ScheduleWindow},
+		{"raw body with its own schedule", func(c *Commands) error {
+			_, err := c.SendScheduledCommand(rawBody(), ScheduleRecurring, map[string]interface{}{"name": "own"})
+			return err
+		}, ScheduleRecurring},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, sent := captureServer(t)
+			commands := (&Commands{Request: testRequest(server.URL)}).WithSchedule(schedule)
+			if err := tt.send(commands); err != nil {
+				t.Fatalf("send error = %v", err)
+			}
+			if len(*sent) != 1 {
+				t.Fatalf("sent %d commands, want 1", len(*sent))
+			}
+			got := (*sent)[0]
+			if got.Schedule != tt.wantSchedule || len(got.ScheduleArgs) == 0 {
+				t.Errorf("sent schedule %q with args %v, want %q", got.Schedule, got.ScheduleArgs, tt.wantSchedule)
+			}
+		})
+	}
+}
+
+func TestSendCommandRequestNil(t *testing.T) {
+	server, sent := captureServer(t)
+	plain := &Commands{Request: testRequest(server.URL)}
+	schedule := WindowSchedule(time.Now(), time.Now().Add(time.Hour), TimeWindow{}, TimeTypeConsole)
+
+	for _, commands := range []*Commands{plain, plain.WithSchedule(schedule)} {
+		if _, err := commands.SendCommandRequest(nil); err == nil {
+			t.Error("SendCommandRequest(nil) succeeded")
+		}
+	}
+	if len(*sent) != 0 {
+		t.Errorf("sent %d commands for a nil request", len(*sent))
+	}
+}
@@ -0,0 +1,232 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ScriptAction is the action a DPC script step performs
+type ScriptAction string
+
+const (
+	ScriptActionLaunch    ScriptAction = "LAUNCH"    // Start an activity or service
+	ScriptActionBroadcast ScriptAction = "BROADCAST" // Send a broadcast intent
+	ScriptActionSleep     ScriptAction = "SLEEP"     // Pause before the next step
+)
+
+// LaunchType is the kind of component started by a LAUNCH step
+type LaunchType string
+
+const (
+	LaunchTypeActivity LaunchType = "ACTIVITY"
+	LaunchTypeService  LaunchType = "SERVICE"
+)
+
+// ServiceType decides how a launched service is started
+type ServiceType string
+
+const (
+	ServiceTypeForeground ServiceType = "FOREGROUND"
+	ServiceTypeBackground ServiceType = "BACKGROUND"
+)
+
+// ScriptActionParams holds the parameters of a script step
+type ScriptActionParams struct {
+	ComponentName string                 `json:"componentName,omitempty"` // "package/class", e.g. "com.example/.MainActivity"
+	IntentAction  string                 `json:"intentAction,omitempty"`
+	ServiceType   ServiceType            `json:"serviceType,omitempty"`
+	Extras        map[string]interface{} `json:"extras,omitempty"`
+	DelayMillis   int                    `json:"delay,omitempty"` // Used by SLEEP steps
+}
+
+// Script is a single step run by the device policy controller
+type Script struct {
+	Action       ScriptAction       `json:"action"`
+	LaunchType   LaunchType         `json:"launchType,omitempty"`
+	ActionParams ScriptActionParams `json:"actionParams"`
+}
+
+// LaunchActivityScript starts an activity
+func LaunchActivityScript(componentName, intentAction string, extras map[string]interface{}) Script {
+	return Script{
+		Action:     ScriptActionLaunch,
+		LaunchType: LaunchTypeActivity,
+		ActionParams: ScriptActionParams{
+			ComponentName: componentName,
+			IntentAction:  intentAction,
+			Extras:        extras,
+		},
+	}
+}
+
+// LaunchServiceScript starts a service
+func LaunchServiceScript(componentName, intentAction string, serviceType ServiceType, extras map[string]interface{}) Script {
+	return Script{
+		Action:     ScriptActionLaunch,
+		LaunchType: LaunchTypeService,
+		ActionParams: ScriptActionParams{
+			ComponentName: componentName,
+			IntentAction:  intentAction,
+			ServiceType:   serviceType,
+			Extras:        extras,
+		},
+	}
+}
+
+// BroadcastScript sends a broadcast intent, optionally to a single component
+func BroadcastScript(intentAction, componentName string, extras map[string]interface{}) Script {
+	return Script{
+		Action: ScriptActionBroadcast,
+		ActionParams: ScriptActionParams{
+			ComponentName: componentName,
+			IntentAction:  intentAction,
+			Extras:        extras,
+		},
+	}
+}
+
+// SleepScript waits before running the next step
+func SleepScript(delayMillis int) Script {
+	return Script{
+		Action:       ScriptActionSleep,
+		ActionParams: ScriptActionParams{DelayMillis: delayMillis},
+	}
+}
+
+// componentNamePattern matches "package/class" where class may be relative, e.g. "com.example/.Main"
+var componentNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+/\.?[A-Za-z][A-Za-z0-9_$]*(\.[A-Za-z][A-Za-z0-9_$]*)*$`)
+
+// Validate checks that the step has the parameters its action needs
+func (s Script) Validate() error {
+	params := s.ActionParams
+	if params.ComponentName != "" && !componentNamePattern.MatchString(params.ComponentName) {
+		return fmt.Errorf("invalid component name %q, expected package/class", params.ComponentName)
+	}
+
+	switch s.Action {
+	case ScriptActionLaunch:
+		switch s.LaunchType {
+		case LaunchTypeActivity:
+			if params.ServiceType != "" {
+				return errors.New("service type is only valid when launching a service")
+			}
+		case LaunchTypeService:
+			switch params.ServiceType {
+			case "", ServiceTypeForeground, ServiceTypeBackground:
+			default:
+				return fmt.Errorf("unknown service type %q", params.ServiceType)
+			}
+		default:
+			return fmt.Errorf("unknown launch type %q", s.LaunchType)
+		}
+		if params.ComponentName == "" {
+			return errors.New("LAUNCH requires a component name")
+		}
+	case ScriptActionBroadcast:
+		if s.LaunchType != "" {
+			return errors.New("launch type is only valid for LAUNCH steps")
+		}
+		if params.IntentAction == "" {
+			return errors.New("BROADCAST requires an intent action")
+		}
+	case ScriptActionSleep:
+		if params.DelayMillis <= 0 {
+			return errors.New("SLEEP requires a positive delay")
+		}
+	default:
+		return fmt.Errorf("unknown script action %q", s.Action)
+	}
+	return nil
+}
+
+// CustomSettingsConfig is the custom_settings_config of an UPDATE_DEVICE_CONFIG
+// command: an ordered list of DPC scripts plus named settings sections such as
+// "display_settings". Build one with NewCustomSettings.
+type CustomSettingsConfig struct {
+	Scripts  []Script
+	Sections map[string]map[string]interface{}
+}
+
+// NewCustomSettings returns an empty config
+func NewCustomSettings() *CustomSettingsConfig {
+	return &CustomSettingsConfig{}
+}
+
+// AddScript appends steps to the script
+func (cfg *CustomSettingsConfig) AddScript(scripts ...Script) *CustomSettingsConfig {
+	cfg.Scripts = append(cfg.Scripts, scripts...)
+	return cfg
+}
+
+// SetSection sets a settings section, replacing any previous values
+func (cfg *CustomSettingsConfig) SetSection(name string, values map[string]interface{}) *CustomSettingsConfig {
+	if cfg.Sections == nil {
+		cfg.Sections = map[string]map[string]interface{}{}
+	}
+	cfg.Sections[name] = values
+	return cfg
+}
+
+// Validate checks every script step and section
+func (cfg *CustomSettingsConfig) Validate() error {
+	if cfg == nil || (len(cfg.Scripts) == 0 && len(cfg.Sections) == 0) {
+		return errors.New("custom settings config is empty")
+	}
+
+	var errs []error
+	for i, script := range cfg.Scripts {
+		if err := script.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("script step %d: %w", i+1, err))
+		}
+	}
+	for name, values := range cfg.Sections {
+		switch {
+		case name == "" || name == "scripts":
+			errs = append(errs, fmt.Errorf("invalid section name %q", name))
+		case len(values) == 0:
+			errs = append(errs, fmt.Errorf("section %q is empty", name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid custom settings config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// MarshalJSON encodes the sections alongside the scripts as a single object
+func (cfg CustomSettingsConfig) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(cfg.Sections)+1)
+	for name, values := range cfg.Sections {
+		out[name] = values
+	}
+	if len(cfg.Scripts) > 0 {
+		out["scripts"] = cfg.Scripts
+	}
+	return json.Marshal(out)
+}
+
+// UpdateCustomSettings applies a custom settings config to the target devices.
+// An empty deviceType defaults to DeviceTypeAll.
+func (c *Commands) UpdateCustomSettings(target Target, deviceType DeviceType, config *CustomSettingsConfig) (*CommandResponse, error) {
+	return c.UpdateCustomSettingsContext(context.Background(), target, deviceType, config)
+}
+
+// UpdateCustomSettingsContext is like UpdateCustomSettings but carries ctx for cancellation and deadlines
+func (c *Commands) UpdateCustomSettingsContext(ctx context.Context, target Target, deviceType DeviceType, config *CustomSettingsConfig) (*CommandResponse, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if deviceType == "" {
+		deviceType = DeviceTypeAll
+	}
+
+	req := targetCommand(target, CommandUpdateDeviceConfig, map[string]interface{}{
+		"custom_settings_config": config,
+	})
+	req.DeviceType = deviceType
+	return c.SendCommandRequestContext(ctx, req)
+}
@@ -0,0 +1,102 @@
+package resources
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCustomSettingsMarshalJSON(t *testing.T) {
+	cfg := NewCustomSettings().
+		SetSection("display_settings", map[string]interface{}{"brightness": 80}).
+		SetSection("sound_settings", map[string]interface{}{"muted": true}).
+		AddScript(SleepScript(500), BroadcastScript("com.example.PING", "", nil))
+
+	encoded, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"display_settings":{"brightness":80},"scripts":[{"action":"SLEEP","actionParams":{"delay":500}},{"action":"BROADCAST","actionParams":{"intentAction":"com.example.PING"}}],"sound_settings":{"muted":true}}`
+	if string(encoded) != want {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", encoded, want)
+	}
+
+	sectionsOnly, err := json.Marshal(NewCustomSettings().SetSection("display_settings", map[string]interface{}{"brightness": 80}))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(sectionsOnly), "scripts") {
+		t.Errorf("Marshal() = %s, want no scripts key without scripts", sectionsOnly)
+	}
+}
+
+func TestCustomSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *CustomSettingsConfig
+		wantErr string
+	}{
+		{"valid", NewCustomSettings().SetSection("display_settings", map[string]interface{}{"brightness": 80}).AddScript(SleepScript(1)), ""},
+		{"nil", nil, "is empty"},
+		{"empty", NewCustomSettings(), "is empty"},
+		{"section named scripts", NewCustomSettings().SetSection("scripts", map[string]interface{}{"a": 1}), `invalid section name "scripts"`},
+		{"unnamed section", NewCustomSettings().SetSection("", map[string]interface{}{"a": 1}), `invalid section name ""`},
+		{"empty section", NewCustomSettings().SetSection("display_settings", nil), `section "display_settings" is empty`},
+		{"bad step", NewCustomSettings().AddScript(SleepScript(1), SleepScript(0)), "script step 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScriptValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  Script
+		wantErr string
+	}{
+		{"activity", LaunchActivityScript("com.example/.MainActivity", "", nil), ""},
+		{"service", LaunchServiceScript("com.example/com.example.Sync", "", ServiceTypeForeground, nil), ""},
+		{"nested class", LaunchActivityScript("com.example/.Main$Inner", "", nil), ""},
+		{"broadcast", BroadcastScript("com.example.PING", "com.example/.Receiver", nil), ""},
+		{"sleep", SleepScript(1000), ""},
+		{"launch without component", LaunchActivityScript("", "android.intent.action.MAIN", nil), "LAUNCH requires a component name"},
+		{"launch without type", Script{Action: ScriptActionLaunch, ActionParams: ScriptActionParams{ComponentName: "com.example/.Main"}}, `unknown launch type ""`},
+		{"service type on activity", Script{Action: ScriptActionLaunch, LaunchType: LaunchTypeActivity, ActionParams: ScriptActionParams{ComponentName: "com.example/.Main", ServiceType: ServiceTypeBackground}}, "only valid when launching a service"},
+		{"unknown service type", LaunchServiceScript("com.example/.Sync", "", "STICKY", nil), `unknown service type "STICKY"`},
+		{"broadcast with launch type", Script{Action: ScriptActionBroadcast, LaunchType: LaunchTypeService, ActionParams: ScriptActionParams{IntentAction: "com.example.PING"}}, "only valid for LAUNCH steps"},
+		{"broadcast without action", BroadcastScript("", "", nil), "BROADCAST requires an intent action"},
+		{"zero sleep", SleepScript(0), "positive delay"},
+		{"negative sleep", SleepScript(-5), "positive delay"},
+		{"unknown action", Script{Action: "WAIT"}, `unknown script action "WAIT"`},
+		{"component without class", LaunchActivityScript("com.example", "", nil), "invalid component name"},
+		{"component without package dot", LaunchActivityScript("example/.Main", "", nil), "invalid component name"},
+		{"component with empty class", LaunchActivityScript("com.example/", "", nil), "invalid component name"},
+		{"component with spaces", BroadcastScript("com.example.PING", "com.example/.My Receiver", nil), "invalid component name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.script.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
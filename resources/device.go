@@ -1,7 +1,12 @@
 package resources
 
 import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/Hasaber8/esper-go-sdk/requests"
 )
@@ -10,15 +15,127 @@ type Device struct {
 	Request *requests.Request
 }
 
+const devicesEndpoint = "/api/v2/devices"
+
+// DeviceState represents the lifecycle state of a device
+type DeviceState string
+
+const (
+	DeviceStateActive         DeviceState = "ACTIVE"
+	DeviceStateInactive       DeviceState = "INACTIVE"
+	DeviceStateDisabled       DeviceState = "DISABLED"
+	DeviceStateProvisioning   DeviceState = "PROVISIONING"
+	DeviceStateWipeInProgress DeviceState = "WIPE_IN_PROGRESS"
+)
+
+// DeviceInfo is a single device as returned by the devices API.
+// Fields not covered by the struct are available through Raw.
+type DeviceInfo struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	AliasName   string       `json:"alias_name"`
+	Serial      string       `json:"serial"`
+	IMEI        string       `json:"imei"`
+	State       DeviceState  `json:"state"`
+	Platform    string       `json:"platform"`
+	OSVersion   string       `json:"os_version"`
+	BuildNumber string       `json:"build_number"`
+	Brand       string       `json:"brand"`
+	Model       string       `json:"model"`
+	Battery     *BatteryInfo `json:"battery,omitempty"`
+	Network     *NetworkInfo `json:"network,omitempty"`
+	Groups      []string     `json:"groups"`
+	Tags        []string     `json:"tags"`
+	LastSeen    *time.Time   `json:"last_seen"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// BatteryInfo holds the last reported battery status of a device
+type BatteryInfo struct {
+	Level       int     `json:"level"`
+	Status      string  `json:"status"`
+	Health      string  `json:"health"`
+	Temperature float64 `json:"temperature"`
+}
+
+// NetworkInfo holds the last reported network status of a device
+type NetworkInfo struct {
+	WifiSSID   string `json:"wifi_ssid"`
+	IPAddress  string `json:"ip_address"`
+	MACAddress string `json:"mac_address"`
+	Carrier    string `json:"carrier"`
+	SignalDBM  int    `json:"signal_dbm"`
+}
+
+// UnmarshalJSON decodes a device and keeps a copy of the raw JSON
+func (d *DeviceInfo) UnmarshalJSON(data []byte) error {
+	type plain DeviceInfo
+	if err := json.Unmarshal(data, (*plain)(d)); err != nil {
+		return err
+	}
+	d.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// DeviceList is a single page of devices
+type DeviceList = Page[DeviceInfo]
+
 // List devices with optional filters
-func (d *Device) List(filters map[string]string) (*requests.APIResponse, error) {
-	endpoint := "/api/v2/devices"
+func (d *Device) List(filters map[string]string) (*DeviceList, error) {
+	return d.ListContext(context.Background(), filters)
+}
 
-	// Build query parameters
+// ListContext lists devices with optional filters, honouring ctx cancellation
+func (d *Device) ListContext(ctx context.Context, filters map[string]string) (*DeviceList, error) {
+	return d.listPage(ctx, filterValues(filters))
+}
+
+// ListFiltered lists a single page of devices matching a typed filter.
+// The filter is validated before any request is sent; nil matches every device.
+func (d *Device) ListFiltered(filter *DeviceFilter) (*DeviceList, error) {
+	return d.ListFilteredContext(context.Background(), filter)
+}
+
+// ListFilteredContext is like ListFiltered but carries ctx for cancellation and deadlines
+func (d *Device) ListFilteredContext(ctx context.Context, filter *DeviceFilter) (*DeviceList, error) {
+	query, err := filter.Values()
+	if err != nil {
+		return nil, err
+	}
+	return d.listPage(ctx, query)
+}
+
+// All iterates over every device matching filter, following the API's next
+// links page by page. The filter's Limit sets the page size. Iteration stops
+// after the first error, which is yielded together with a zero DeviceInfo.
+func (d *Device) All(ctx context.Context, filter *DeviceFilter) iter.Seq2[DeviceInfo, error] {
+	query, err := filter.Values()
+	if err != nil {
+		return failed[DeviceInfo](err)
+	}
+	return paginate[DeviceInfo](ctx, d.Request, devicesEndpoint, query)
+}
+
+// ListAll collects every device matching filter into a slice
+func (d *Device) ListAll(ctx context.Context, filter *DeviceFilter) ([]DeviceInfo, error) {
+	return collect(d.All(ctx, filter))
+}
+
+// listPage fetches a single page of devices
+func (d *Device) listPage(ctx context.Context, queryParams url.Values) (*DeviceList, error) {
+	var list DeviceList
+	if err := d.Request.Do(ctx, http.MethodGet, devicesEndpoint, queryParams, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// filterValues converts a filter map into query parameters
+func filterValues(filters map[string]string) url.Values {
 	queryParams := url.Values{}
 	for key, value := range filters {
 		queryParams.Add(key, value)
 	}
-
-	return d.Request.Get(endpoint, queryParams)
+	return queryParams
 }
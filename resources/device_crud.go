@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// DevicePatch holds the device fields that can be updated.
+// Nil fields are left unchanged.
+type DevicePatch struct {
+	AliasName *string   `json:"alias_name,omitempty"`
+	Tags      *[]string `json:"tags,omitempty"`
+}
+
+// Get fetches a single device by ID
+func (d *Device) Get(deviceID string) (*DeviceInfo, error) {
+	return d.GetContext(context.Background(), deviceID)
+}
+
+// GetContext is like Get but carries ctx for cancellation and deadlines
+func (d *Device) GetContext(ctx context.Context, deviceID string) (*DeviceInfo, error) {
+	var device DeviceInfo
+	if err := d.Request.Do(ctx, http.MethodGet, deviceEndpoint(deviceID), nil, nil, &device); err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// Update applies patch to a device and returns the updated device
+func (d *Device) Update(deviceID string, patch DevicePatch) (*DeviceInfo, error) {
+	return d.UpdateContext(context.Background(), deviceID, patch)
+}
+
+// UpdateContext is like Update but carries ctx for cancellation and deadlines
+func (d *Device) UpdateContext(ctx context.Context, deviceID string, patch DevicePatch) (*DeviceInfo, error) {
+	var device DeviceInfo
+	if err := d.Request.Do(ctx, http.MethodPatch, deviceEndpoint(deviceID), nil, patch, &device); err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// Delete removes a device from the enterprise, retiring it
+func (d *Device) Delete(deviceID string) error {
+	return d.DeleteContext(context.Background(), deviceID)
+}
+
+// DeleteContext is like Delete but carries ctx for cancellation and deadlines
+func (d *Device) DeleteContext(ctx context.Context, deviceID string) error {
+	return d.Request.Do(ctx, http.MethodDelete, deviceEndpoint(deviceID), nil, nil, nil)
+}
+
+// SetAlias sets the alias name of a device
+func (d *Device) SetAlias(deviceID, alias string) (*DeviceInfo, error) {
+	return d.SetAliasContext(context.Background(), deviceID, alias)
+}
+
+// SetAliasContext is like SetAlias but carries ctx for cancellation and deadlines
+func (d *Device) SetAliasContext(ctx context.Context, deviceID, alias string) (*DeviceInfo, error) {
+	return d.UpdateContext(ctx, deviceID, DevicePatch{AliasName: &alias})
+}
+
+// SetTags replaces all tags on a device
+func (d *Device) SetTags(deviceID string, tags []string) (*DeviceInfo, error) {
+	return d.SetTagsContext(context.Background(), deviceID, tags)
+}
+
+// SetTagsContext is like SetTags but carries ctx for cancellation and deadlines
+func (d *Device) SetTagsContext(ctx context.Context, deviceID string, tags []string) (*DeviceInfo, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	return d.UpdateContext(ctx, deviceID, DevicePatch{Tags: &tags})
+}
+
+// AddTags adds tags to a device, keeping the existing ones
+func (d *Device) AddTags(deviceID string, tags ...string) (*DeviceInfo, error) {
+	return d.AddTagsContext(context.Background(), deviceID, tags...)
+}
+
+// AddTagsContext is like AddTags but carries ctx for cancellation and deadlines
+func (d *Device) AddTagsContext(ctx context.Context, deviceID string, tags ...string) (*DeviceInfo, error) {
+	device, err := d.GetContext(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := slices.Clone(device.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return d.SetTagsContext(ctx, deviceID, merged)
+}
+
+// RemoveTags removes tags from a device, keeping the others
+func (d *Device) RemoveTags(deviceID string, tags ...string) (*DeviceInfo, error) {
+	return d.RemoveTagsContext(context.Background(), deviceID, tags...)
+}
+
+// RemoveTagsContext is like RemoveTags but carries ctx for cancellation and deadlines
+func (d *Device) RemoveTagsContext(ctx context.Context, deviceID string, tags ...string) (*DeviceInfo, error) {
+	device, err := d.GetContext(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(device.Tags), func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	return d.SetTagsContext(ctx, deviceID, remaining)
+}
+
+func deviceEndpoint(deviceID string) string {
+	return fmt.Sprintf("%s/%s", devicesEndpoint, deviceID)
+}
@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/Hasaber8/esper-go-sdk/requests"
+)
+
+// deviceServer serves a single device with tags, applying PATCH bodies to it
+// and recording the raw body of each PATCH and the method of each request
+func deviceServer(t *testing.T, tags ...string) (server *httptest.Server, patches *[]string, methods *[]string) {
+	t.Helper()
+	patches, methods = new([]string), new([]string)
+	device := map[string]interface{}{"id": "d1", "alias_name": "old", "tags": tags}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*methods = append(*methods, r.Method)
+		if r.URL.Path != devicesEndpoint+"/d1" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			*patches = append(*patches, string(body))
+			json.Unmarshal(body, &device)
+		}
+		json.NewEncoder(w).Encode(device)
+	}))
+	t.Cleanup(server.Close)
+	return server, patches, methods
+}
+
+func TestDeviceTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		tags      []string
+		call      func(d *Device) (*DeviceInfo, error)
+		wantPatch string
+		wantTags  []string
+	}{
+		{"add keeps existing tags without duplicates", []string{"a", "b"}, func(d *Device) (*DeviceInfo, error) {
+			return d.AddTags("d1", "b", "c", "c")
+		}, `{"tags":["a","b","c"]}`, []string{"a", "b", "c"}},
+		{"add to an untagged device", nil, func(d *Device) (*DeviceInfo, error) {
+			return d.AddTags("d1", "a")
+		}, `{"tags":["a"]}`, []string{"a"}},
+		{"remove keeps the others", []string{"a", "b", "c"}, func(d *Device) (*DeviceInfo, error) {
+			return d.RemoveTags("d1", "b", "missing")
+		}, `{"tags":["a","c"]}`, []string{"a", "c"}},
+		{"remove the last tag", []string{"a"}, func(d *Device) (*DeviceInfo, error) {
+			return d.RemoveTags("d1", "a")
+		}, `{"tags":[]}`, []string{}},
+		{"set nil clears tags", []string{"a"}, func(d *Device) (*DeviceInfo, error) {
+			return d.SetTags("d1", nil)
+		}, `{"tags":[]}`, []string{}},
+		{"set alias leaves tags alone", []string{"a"}, func(d *Device) (*DeviceInfo, error) {
+			return d.SetAlias("d1", "lobby")
+		}, `{"alias_name":"lobby"}`, []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, patches, _ := deviceServer(t, tt.tags...)
+			device, err := tt.call(&Device{Request: testRequest(server.URL)})
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(*patches) != 1 || (*patches)[0] != tt.wantPatch {
+				t.Errorf("sent patches %q, want %q", *patches, tt.wantPatch)
+			}
+			if !slices.Equal(device.Tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", device.Tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestDevicePatchOmitsNilFields(t *testing.T) {
+	alias := "lobby"
+	tests := []struct {
+		patch DevicePatch
+		want  string
+	}{
+		{DevicePatch{}, `{}`},
+		{DevicePatch{AliasName: &alias}, `{"alias_name":"lobby"}`},
+		{DevicePatch{Tags: &[]string{}}, `{"tags":[]}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.patch)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("Marshal(%+v) = %s, %v, want %s", tt.patch, data, err, tt.want)
+		}
+	}
+}
+
+func TestDeviceGetAndDelete(t *testing.T) {
+	server, _, methods := deviceServer(t, "a")
+	device := &Device{Request: testRequest(server.URL)}
+
+	got, err := device.Get("d1")
+	if err != nil || got.ID != "d1" || got.AliasName != "old" || len(got.Raw) == 0 {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+	if err := device.Delete("d1"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := device.Get("missing"); !requests.IsNotFound(err) {
+		t.Errorf("Get() of an unknown device error = %v, want not found", err)
+	}
+	if !slices.Equal(*methods, []string{http.MethodGet, http.MethodDelete, http.MethodGet}) {
+		t.Errorf("methods = %v", *methods)
+	}
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeviceFilter describes the supported query fields for listing devices.
+// The zero value matches every device.
+type DeviceFilter struct {
+	Name     string      // Exact device name
+	Serial   string      // Hardware serial number
+	IMEI     string      // IMEI of the device
+	State    DeviceState // Lifecycle state
+	Group    string      // Group ID the device belongs to
+	Tags     []string    // Devices carrying all of these tags
+	Search   string      // Free text search across name, serial and IMEI
+	Ordering string      // Field to order by, prefix with "-" for descending
+
+	LastSeenAfter  time.Time // Only devices seen at or after this time
+	LastSeenBefore time.Time // Only devices seen at or before this time
+
+	Limit  int // Page size, 0 uses the API default
+	Offset int // Number of devices to skip
+}
+
+// deviceOrderingFields lists the fields the devices API can order by
+var deviceOrderingFields = map[string]bool{
+	"name":       true,
+	"serial":     true,
+	"state":      true,
+	"last_seen":  true,
+	"created_on": true,
+	"updated_on": true,
+}
+
+var validDeviceStates = map[DeviceState]bool{
+	DeviceStateActive:         true,
+	DeviceStateInactive:       true,
+	DeviceStateDisabled:       true,
+	DeviceStateProvisioning:   true,
+	DeviceStateWipeInProgress: true,
+}
+
+// Validate checks the filter for invalid values and combinations
+func (f *DeviceFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	var errs []error
+	if f.State != "" && !validDeviceStates[f.State] {
+		errs = append(errs, fmt.Errorf("unknown device state %q", f.State))
+	}
+	if f.Ordering != "" && !deviceOrderingFields[strings.TrimPrefix(f.Ordering, "-")] {
+		errs = append(errs, fmt.Errorf("cannot order devices by %q", f.Ordering))
+	}
+	for _, tag := range f.Tags {
+		if strings.TrimSpace(tag) == "" {
+			errs = append(errs, errors.New("tags must not be empty"))
+			break
+		}
+	}
+	if !f.LastSeenAfter.IsZero() && !f.LastSeenBefore.IsZero() && f.LastSeenAfter.After(f.LastSeenBefore) {
+		errs = append(errs, errors.New("last seen after must not be later than last seen before"))
+	}
+	errs = append(errs, validatePageParams(f.Limit, f.Offset)...)
+	if f.Search != "" && (f.Name != "" || f.Serial != "" || f.IMEI != "") {
+		errs = append(errs, errors.New("search cannot be combined with name, serial or IMEI"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid device filter: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Values validates the filter and encodes it as query parameters
+func (f *DeviceFilter) Values() (url.Values, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	queryParams := url.Values{}
+	if f == nil {
+		return queryParams, nil
+	}
+
+	setIfNotEmpty(queryParams, "name", f.Name)
+	setIfNotEmpty(queryParams, "serial", f.Serial)
+	setIfNotEmpty(queryParams, "imei", f.IMEI)
+	setIfNotEmpty(queryParams, "state", string(f.State))
+	setIfNotEmpty(queryParams, "group_id", f.Group)
+	setIfNotEmpty(queryParams, "tags", strings.Join(f.Tags, ","))
+	setIfNotEmpty(queryParams, "search", f.Search)
+	setIfNotEmpty(queryParams, "ordering", f.Ordering)
+
+	if !f.LastSeenAfter.IsZero() {
+		queryParams.Set("last_seen__gte", f.LastSeenAfter.UTC().Format(time.RFC3339))
+	}
+	if !f.LastSeenBefore.IsZero() {
+		queryParams.Set("last_seen__lte", f.LastSeenBefore.UTC().Format(time.RFC3339))
+	}
+	setPageParams(queryParams, f.Limit, f.Offset)
+	return queryParams, nil
+}
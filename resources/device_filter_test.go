@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeviceFilterValues(t *testing.T) {
+	seenAfter := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	seenBefore := seenAfter.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		filter *DeviceFilter
+		want   url.Values
+	}{
+		{"nil", nil, url.Values{}},
+		{"zero", &DeviceFilter{}, url.Values{}},
+		{
+			name: "all fields",
+			filter: &DeviceFilter{
+				Name:           "kiosk-1",
+				Serial:         "SN1",
+				IMEI:           "3500",
+				State:          DeviceStateActive,
+				Group:          "g1",
+				Tags:           []string{"lobby", "floor-2"},
+				Ordering:       "-last_seen",
+				LastSeenAfter:  seenAfter,
+				LastSeenBefore: seenBefore,
+				Limit:          25,
+				Offset:         50,
+			},
+			want: url.Values{
+				"name":           {"kiosk-1"},
+				"serial":         {"SN1"},
+				"imei":           {"3500"},
+				"state":          {"ACTIVE"},
+				"group_id":       {"g1"},
+				"tags":           {"lobby,floor-2"},
+				"ordering":       {"-last_seen"},
+				"last_seen__gte": {"2024-01-02T03:04:05Z"},
+				"last_seen__lte": {"2024-01-02T04:04:05Z"},
+				"limit":          {"25"},
+				"offset":         {"50"},
+			},
+		},
+		{"search", &DeviceFilter{Search: "lobby"}, url.Values{"search": {"lobby"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Values()
+			if err != nil {
+				t.Fatalf("Values() error = %v", err)
+			}
+			if got.Encode() != tt.want.Encode() {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceFilterValidate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		filter   *DeviceFilter
+		wantErrs []string
+	}{
+		{"unknown state", &DeviceFilter{State: "ONLINE"}, []string{`unknown device state "ONLINE"`}},
+		{"unknown ordering", &DeviceFilter{Ordering: "-imei"}, []string{`cannot order devices by "-imei"`}},
+		{"empty tag", &DeviceFilter{Tags: []string{"a", " "}}, []string{"tags must not be empty"}},
+		{"inverted range", &DeviceFilter{LastSeenAfter: now, LastSeenBefore: now.Add(-time.Hour)}, []string{"last seen after"}},
+		{"search with name", &DeviceFilter{Search: "x", Name: "y"}, []string{"search cannot be combined"}},
+		{
+			name:     "every error is reported",
+			filter:   &DeviceFilter{Limit: maxPageSize + 1, Offset: -1, State: "ONLINE"},
+			wantErrs: []string{"limit must be between", "offset must not be negative", "unknown device state"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if err == nil {
+				t.Fatal("Validate() succeeded")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %q, want it to mention %q", err, want)
+				}
+			}
+			var joined interface{ Unwrap() []error }
+			if !errors.As(err, &joined) || len(joined.Unwrap()) != len(tt.wantErrs) {
+				t.Errorf("Validate() = %q, want %d joined errors", err, len(tt.wantErrs))
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Hasaber8/esper-go-sdk/requests"
+)
+
+const (
+	defaultPageSize = 100 // Page size used by iterators when none is given
+	maxPageSize     = 500 // Largest page size accepted by the list endpoints
+)
+
+// Page is the envelope shared by all paginated list responses
+type Page[T any] struct {
+	Count    int    `json:"count"`
+	Next     string `json:"next"`
+	Previous string `json:"previous"`
+	Results  []T    `json:"results"`
+}
+
+// paginate iterates over every item at endpoint, following the API's next
+// links page by page. Iteration stops after the first error, which is yielded
+// together with a zero T.
+func paginate[T any](ctx context.Context, request *requests.Request, endpoint string, query url.Values) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		// Work on a copy so the sequence can be iterated again from the start
+		query := maps.Clone(query)
+		if query == nil {
+			query = url.Values{}
+		}
+		if query.Get("limit") == "" {
+			query.Set("limit", strconv.Itoa(defaultPageSize))
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var current Page[T]
+			if err := request.Do(ctx, http.MethodGet, endpoint, query, nil, &current); err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range current.Results {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if current.Next == "" || len(current.Results) == 0 {
+				return
+			}
+			var err error
+			if query, err = nextPageQuery(current.Next); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// failed returns a sequence that yields err and stops, for list calls whose
+// filter is rejected before any request is sent
+func failed[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// collect gathers every item of seq into a slice, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// validatePageParams checks the limit and offset of a list filter
+func validatePageParams(limit, offset int) []error {
+	var errs []error
+	if limit < 0 || limit > maxPageSize {
+		errs = append(errs, fmt.Errorf("limit must be between 0 and %d", maxPageSize))
+	}
+	if offset < 0 {
+		errs = append(errs, errors.New("offset must not be negative"))
+	}
+	return errs
+}
+
+// setPageParams adds the limit and offset of a list filter to query when set
+func setPageParams(query url.Values, limit, offset int) {
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+}
+
+// setIfNotEmpty sets key in query unless value is empty
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// nextPageQuery extracts the query parameters from a "next" page link
+func nextPageQuery(next string) (url.Values, error) {
+	u, err := url.Parse(next)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next page link %q: %w", next, err)
+	}
+	return u.Query(), nil
+}
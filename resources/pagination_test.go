@@ -0,0 +1,231 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Hasaber8/esper-go-sdk/requests"
+)
+
+func testRequest(baseURL string) *requests.Request {
+	return &requests.Request{
+		BaseURL:      baseURL,
+		EnterpriseID: "enterprise",
+		Auth:         requests.Auth{Token: "token"},
+		HTTPClient:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// pagedServer serves ids as {"id": ...} objects, honouring limit and offset
+// and linking to the next page the way the Esper API does. Every query it
+// receives is recorded in queries.
+func pagedServer(t *testing.T, ids ...string) (server *httptest.Server, queries *[]url.Values) {
+	t.Helper()
+	queries = new([]url.Values)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*queries = append(*queries, query)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		end := min(offset+limit, len(ids))
+
+		results := []map[string]string{}
+		for _, id := range ids[min(offset, end):end] {
+			results = append(results, map[string]string{"id": id})
+		}
+		next := ""
+		if end < len(ids) {
+			next = fmt.Sprintf("http://%s%s?limit=%d&offset=%d", r.Host, r.URL.Path, limit, end)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   len(ids),
+			"next":    next,
+			"results": results,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, queries
+}
+
+func deviceIDs(t *testing.T, seq func(func(DeviceInfo, error) bool)) []string {
+	t.Helper()
+	var ids []string
+	for device, err := range seq {
+		if err != nil {
+			t.Fatalf("iteration failed: %v", err)
+		}
+		ids = append(ids, device.ID)
+	}
+	return ids
+}
+
+func TestDeviceAllFollowsNextLinks(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       []string
+		filter    *DeviceFilter
+		wantPages int
+	}{
+		{"empty", nil, nil, 1},
+		{"single page with default size", []string{"a", "b", "c"}, nil, 1},
+		{"several pages", []string{"a", "b", "c", "d", "e"}, &DeviceFilter{Limit: 2}, 3},
+		{"exact multiple of page size", []string{"a", "b", "c", "d"}, &DeviceFilter{Limit: 2}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, queries := pagedServer(t, tt.ids...)
+			device := &Device{Request: testRequest(server.URL)}
+
+			got := deviceIDs(t, device.All(context.Background(), tt.filter))
+			if !slices.Equal(got, tt.ids) {
+				t.Errorf("All() = %v, want %v", got, tt.ids)
+			}
+			if len(*queries) != tt.wantPages {
+				t.Errorf("fetched %d pages, want %d", len(*queries), tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestDeviceAllCanBeIteratedTwice(t *testing.T) {
+	server, _ := pagedServer(t, "a", "b", "c")
+	device := &Device{Request: testRequest(server.URL)}
+	seq := device.All(context.Background(), &DeviceFilter{Limit: 2})
+
+	first := deviceIDs(t, seq)
+	second := deviceIDs(t, seq)
+	if !slices.Equal(first, []string{"a", "b", "c"}) || !slices.Equal(second, first) {
+		t.Errorf("first pass = %v, second pass = %v", first, second)
+	}
+}
+
+func TestDeviceAllStopsEarly(t *testing.T) {
+	server, queries := pagedServer(t, "a", "b", "c", "d")
+	device := &Device{Request: testRequest(server.URL)}
+
+	for device, err := range device.All(context.Background(), &DeviceFilter{Limit: 2}) {
+		if err != nil || device.ID == "a" {
+			break
+		}
+	}
+	if len(*queries) != 1 {
+		t.Errorf("fetched %d pages after breaking on the first item, want 1", len(*queries))
+	}
+}
+
+func TestDeviceAllStopsOnCancelledContext(t *testing.T) {
+	server, queries := pagedServer(t, "a", "b")
+	device := &Device{Request: testRequest(server.URL)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var gotErr error
+	for _, err := range device.All(ctx, nil) {
+		gotErr = err
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", gotErr)
+	}
+	if len(*queries) != 0 {
+		t.Errorf("fetched %d pages with a cancelled context", len(*queries))
+	}
+}
+
+func TestDeviceAllRejectsInvalidFilter(t *testing.T) {
+	server, queries := pagedServer(t, "a")
+	device := &Device{Request: testRequest(server.URL)}
+
+	_, err := device.ListAll(context.Background(), &DeviceFilter{Limit: -1})
+	if err == nil {
+		t.Fatal("ListAll() with a negative limit succeeded")
+	}
+	if len(*queries) != 0 {
+		t.Errorf("sent %d requests for an invalid filter", len(*queries))
+	}
+}
+
+func TestCommandsAllCanBeIteratedTwice(t *testing.T) {
+	server, queries := pagedServer(t, "r1", "r2", "r3")
+	commands := &Commands{Request: testRequest(server.URL)}
+	seq := commands.All(context.Background(), &CommandFilter{Command: CommandReboot, Limit: 2})
+
+	for pass := 1; pass <= 2; pass++ {
+		var ids []string
+		for command, err := range seq {
+			if err != nil {
+				t.Fatalf("pass %d: %v", pass, err)
+			}
+			ids = append(ids, command.ID)
+		}
+		if !slices.Equal(ids, []string{"r1", "r2", "r3"}) {
+			t.Errorf("pass %d = %v", pass, ids)
+		}
+	}
+	if got := (*queries)[2]; got.Get("command") != string(CommandReboot) || got.Get("offset") != "" {
+		t.Errorf("second pass did not restart from the filtered first page: %v", got)
+	}
+}
+
+func TestListAllScheduledFollowsNextLinks(t *testing.T) {
+	server, queries := pagedServer(t, "s1", "s2", "s3")
+	commands := &Commands{Request: testRequest(server.URL)}
+
+	scheduled, err := commands.ListAllScheduled(context.Background(), &ScheduledCommandFilter{Status: ScheduledCommandActive, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAllScheduled() error = %v", err)
+	}
+	var ids []string
+	for _, s := range scheduled {
+		ids = append(ids, s.ID)
+	}
+	if !slices.Equal(ids, []string{"s1", "s2", "s3"}) {
+		t.Errorf("ListAllScheduled() = %v", ids)
+	}
+	if len(*queries) != 2 || (*queries)[0].Get("status") != string(ScheduledCommandActive) {
+		t.Errorf("queries = %v", *queries)
+	}
+
+	if _, err := commands.ListAllScheduled(context.Background(), &ScheduledCommandFilter{Limit: maxPageSize + 1}); err == nil {
+		t.Error("ListAllScheduled() accepted an oversized limit")
+	}
+	if len(*queries) != 2 {
+		t.Errorf("sent %d requests, want none for an invalid filter", len(*queries)-2)
+	}
+}
+
+func TestPageParams(t *testing.T) {
+	tests := []struct {
+		limit, offset int
+		wantErrs      int
+		wantQuery     string
+	}{
+		{0, 0, 0, ""},
+		{maxPageSize, 10, 0, "limit=500&offset=10"},
+		{25, 0, 0, "limit=25"},
+		{-1, 0, 1, ""},
+		{maxPageSize + 1, -1, 2, ""},
+	}
+	for _, tt := range tests {
+		errs := validatePageParams(tt.limit, tt.offset)
+		if len(errs) != tt.wantErrs {
+			t.Errorf("validatePageParams(%d, %d) = %v, want %d errors", tt.limit, tt.offset, errs, tt.wantErrs)
+		}
+		if tt.wantErrs > 0 {
+			continue
+		}
+		query := url.Values{}
+		setPageParams(query, tt.limit, tt.offset)
+		if got := query.Encode(); got != tt.wantQuery {
+			t.Errorf("setPageParams(%d, %d) = %q, want %q", tt.limit, tt.offset, got, tt.wantQuery)
+		}
+	}
+}
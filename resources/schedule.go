@@ -0,0 +1,245 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeType decides which clock a schedule is evaluated against
+type TimeType string
+
+const (
+	TimeTypeConsole TimeType = "console" // Times are absolute, in the zone of the given time.Time
+	TimeTypeDevice  TimeType = "device"  // Times are wall clock times in each device's local zone
+)
+
+// ClockTime is a time of day with minute precision, as used by schedule windows
+type ClockTime struct {
+	Hour   int
+	Minute int
+}
+
+// ParseClockTime parses a 24 hour "HH:MM" string
+func ParseClockTime(s string) (ClockTime, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil || len(s) != 5 {
+		return ClockTime{}, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return ClockTime{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// String formats the time as "HH:MM"
+func (t ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+func (t ClockTime) valid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60
+}
+
+// WeekdaySet is a set of days of the week
+type WeekdaySet uint8
+
+// Common weekday sets
+const (
+	Workdays WeekdaySet = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+	Weekend  WeekdaySet = 1<<time.Saturday | 1<<time.Sunday
+	EveryDay            = Workdays | Weekend
+)
+
+// NewWeekdaySet returns a set containing days
+func NewWeekdaySet(days ...time.Weekday) WeekdaySet {
+	var set WeekdaySet
+	for _, day := range days {
+		set |= 1 << day
+	}
+	return set
+}
+
+// ParseWeekdaySet builds a set from day names such as "monday" or "Mon"
+func ParseWeekdaySet(names ...string) (WeekdaySet, error) {
+	var set WeekdaySet
+	for _, name := range names {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return 0, fmt.Errorf("unknown weekday %q", name)
+		}
+		set |= 1 << day
+	}
+	return set, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// Has reports whether day is in the set
+func (s WeekdaySet) Has(day time.Weekday) bool {
+	return s&(1<<day) != 0
+}
+
+// Days returns the days in the set, starting from Sunday
+func (s WeekdaySet) Days() []time.Weekday {
+	var days []time.Weekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if s.Has(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// names returns the lower case day names expected by the API
+func (s WeekdaySet) names() []string {
+	var names []string
+	for _, day := range s.Days() {
+		names = append(names, strings.ToLower(day.String()))
+	}
+	return names
+}
+
+// Schedule describes when a command runs. Build one with ImmediateSchedule,
+// WindowSchedule or RecurringSchedule and attach it with Commands.WithSchedule
+// or CommandRequestBuilder.Scheduled.
+type Schedule struct {
+	Type     ScheduleType
+	Name     string // Required for recurring schedules
+	Start    time.Time
+	End      time.Time
+	Window   *TimeWindow // Daily window the command may run in
+	Days     WeekdaySet  // Days the command may run on
+	TimeType TimeType
+}
+
+// TimeWindow is a daily time window. A window ending before it starts wraps past midnight.
+type TimeWindow struct {
+	Start ClockTime
+	End   ClockTime
+}
+
+// ImmediateSchedule runs the command as soon as possible
+func ImmediateSchedule() Schedule {
+	return Schedule{Type: ScheduleImmediate}
+}
+
+// WindowSchedule runs the command once between start and end, inside the daily window
+func WindowSchedule(start, end time.Time, window TimeWindow, timeType TimeType) Schedule {
+	return Schedule{
+		Type:     ScheduleWindow,
+		Start:    start,
+		End:      end,
+		Window:   &window,
+		TimeType: timeType,
+	}
+}
+
+// RecurringSchedule runs the command on the given days between start and end.
+// window may be nil to allow the whole day.
+func RecurringSchedule(name string, start, end time.Time, days WeekdaySet, window *TimeWindow, timeType TimeType) Schedule {
+	return Schedule{
+		Type:     ScheduleRecurring,
+		Name:     name,
+		Start:    start,
+		End:      end,
+		Window:   window,
+		Days:     days,
+		TimeType: timeType,
+	}
+}
+
+// Validate checks the schedule for missing or inconsistent fields
+func (s Schedule) Validate() error {
+	if s.Type == ScheduleImmediate {
+		return nil
+	}
+	if s.Type != ScheduleWindow && s.Type != ScheduleRecurring {
+		return fmt.Errorf("unknown schedule %q", s.Type)
+	}
+
+	var errs []error
+	if s.TimeType != TimeTypeConsole && s.TimeType != TimeTypeDevice {
+		errs = append(errs, fmt.Errorf("unknown time type %q", s.TimeType))
+	}
+	if s.Start.IsZero() || s.End.IsZero() {
+		errs = append(errs, errors.New("start and end are required"))
+	} else if !s.End.After(s.Start) {
+		errs = append(errs, errors.New("end must be after start"))
+	}
+	if s.Window != nil {
+		if !s.Window.Start.valid() || !s.Window.End.valid() {
+			errs = append(errs, errors.New("window times must be valid HH:MM values"))
+		} else if s.Window.Start == s.Window.End {
+			errs = append(errs, errors.New("window start and end must differ"))
+		}
+	}
+
+	switch s.Type {
+	case ScheduleWindow:
+		if s.Window == nil {
+			errs = append(errs, errors.New("window schedules require a time window"))
+		}
+	case ScheduleRecurring:
+		if s.Name == "" {
+			errs = append(errs, errors.New("recurring schedules require a name"))
+		}
+		if s.Days == 0 {
+			errs = append(errs, errors.New("recurring schedules require at least one day"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s schedule: %w", s.Type, errors.Join(errs...))
+	}
+	return nil
+}
+
+// args converts the schedule into the schedule_args of a command request
+func (s Schedule) args() map[string]interface{} {
+	if s.Type == ScheduleImmediate {
+		return nil
+	}
+
+	args := map[string]interface{}{
+		"start_datetime": s.formatDateTime(s.Start),
+		"end_datetime":   s.formatDateTime(s.End),
+		"time_type":      string(s.TimeType),
+	}
+	if s.Name != "" {
+		args["name"] = s.Name
+	}
+	if s.Window != nil {
+		args["window_start_time"] = s.Window.Start.String()
+		args["window_end_time"] = s.Window.End.String()
+	}
+	if s.Days != 0 {
+		args["days"] = s.Days.names()
+	}
+	return args
+}
+
+// formatDateTime keeps the offset for console times. Device times are wall
+// clock values interpreted in each device's zone, so the offset is dropped.
+func (s Schedule) formatDateTime(t time.Time) string {
+	if s.TimeType == TimeTypeDevice {
+		return t.Format("2006-01-02T15:04:05")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// apply sets the schedule fields of req
+func (s Schedule) apply(req *CommandRequest) {
+	req.Schedule = s.Type
+	req.ScheduleArgs = s.args()
+}
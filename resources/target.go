@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Target selects the devices a command is sent to: explicit devices,
+// every device in some groups, or a dynamic set matched by a filter
+type Target struct {
+	Type    CommandType
+	Devices []string
+	Groups  []string
+	Filter  *DynamicFilter
+}
+
+// DynamicFilter matches devices at the time the command is executed.
+// All non-empty fields must match.
+type DynamicFilter struct {
+	Tags       []string      `json:"tags,omitempty"`
+	OSVersions []string      `json:"os_versions,omitempty"`
+	States     []DeviceState `json:"states,omitempty"`
+	Groups     []string      `json:"groups,omitempty"`
+	Search     string        `json:"search,omitempty"`
+}
+
+// isEmpty reports whether the filter would match every device
+func (f *DynamicFilter) isEmpty() bool {
+	return f == nil || (len(f.Tags) == 0 && len(f.OSVersions) == 0 &&
+		len(f.States) == 0 && len(f.Groups) == 0 && f.Search == "")
+}
+
+// DeviceTarget targets the given devices
+func DeviceTarget(devices ...string) Target {
+	return Target{Type: CommandTypeDevice, Devices: devices}
+}
+
+// GroupTarget targets every device in the given groups
+func GroupTarget(groups ...string) Target {
+	return Target{Type: CommandTypeGroup, Groups: groups}
+}
+
+// DynamicTarget targets every device matching filter
+func DynamicTarget(filter DynamicFilter) Target {
+	return Target{Type: CommandTypeDynamic, Filter: &filter}
+}
+
+// Validate checks that the target selects at least one device and carries
+// only the devices, groups or filter matching its type
+func (t Target) Validate() error {
+	switch t.Type {
+	case CommandTypeDevice:
+		if len(t.Devices) == 0 {
+			return errors.New("at least one device is required for a DEVICE command")
+		}
+	case CommandTypeGroup:
+		if len(t.Groups) == 0 {
+			return errors.New("at least one group is required for a GROUP command")
+		}
+	case CommandTypeDynamic:
+		// An empty filter would silently target the whole fleet
+		if t.Filter.isEmpty() {
+			return errors.New("a non-empty filter is required for a DYNAMIC command")
+		}
+	default:
+		return fmt.Errorf("unknown command type %q", t.Type)
+	}
+
+	if t.Type != CommandTypeDevice && len(t.Devices) > 0 {
+		return fmt.Errorf("devices cannot be set on a %s command", t.Type)
+	}
+	if t.Type != CommandTypeGroup && len(t.Groups) > 0 {
+		return fmt.Errorf("groups cannot be set on a %s command", t.Type)
+	}
+	if t.Type != CommandTypeDynamic && t.Filter != nil {
+		return fmt.Errorf("a dynamic filter cannot be set on a %s command", t.Type)
+	}
+	if slices.Contains(t.Devices, "") {
+		return errors.New("device IDs cannot be empty")
+	}
+	if slices.Contains(t.Groups, "") {
+		return errors.New("group IDs cannot be empty")
+	}
+	return nil
+}
+
+// apply sets the target fields of req to copies of the target's, so later
+// changes to req do not write through to the caller's slices
+func (t Target) apply(req *CommandRequest) {
+	req.CommandType = t.Type
+	req.Devices = slices.Clone(t.Devices)
+	req.Groups = slices.Clone(t.Groups)
+	req.DynamicFilter = nil
+	if t.Filter != nil {
+		filter := *t.Filter
+		req.DynamicFilter = &filter
+	}
+}
+
+// targetCommand builds an immediate command request for target
+func targetCommand(target Target, command Command, args map[string]interface{}) *CommandRequest {
+	req := &CommandRequest{
+		Command:  command,
+		Args:     args,
+		Schedule: ScheduleImmediate,
+	}
+	target.apply(req)
+	return req
+}
@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a single invalid field of a command
+type FieldError struct {
+	Field   string // Field name, e.g. "devices" or "command_args.brightness_value"
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError collects every problem found in a command before it is
+// sent. Use errors.As to inspect the individual fields.
+type ValidationError struct {
+	Command Command
+	Fields  []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	if e.Command == "" {
+		return fmt.Sprintf("invalid command: %s", strings.Join(messages, "; "))
+	}
+	return fmt.Sprintf("invalid %s command: %s", e.Command, strings.Join(messages, "; "))
+}
+
+// add records a problem with field
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// has reports whether a problem was recorded for any of fields or their sub-fields
+func (e *ValidationError) has(fields ...string) bool {
+	for _, recorded := range e.Fields {
+		for _, field := range fields {
+			if recorded.Field == field || strings.HasPrefix(recorded.Field, field+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// errOrNil returns e if any problems were recorded
+func (e *ValidationError) errOrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}